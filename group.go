@@ -0,0 +1,83 @@
+// parkjunwoo.com/microstral/group.go
+package mist
+
+import (
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/middleware"
+)
+
+// Group은 공통 경로 접두사와 미들웨어를 공유하는 라우트 묶음입니다.
+// Mist 라우트와 같은 엔진에 등록되므로 전역 미들웨어(로깅, 메트릭, 트레이싱)가 그대로 적용되며,
+// 메트릭/트레이싱의 route 레이블에는 접두사가 포함된 전체 경로가 기록됩니다.
+type Group struct {
+	mist  *Mist
+	group *gin.RouterGroup
+}
+
+// Group: prefix 경로 아래에 라우트 그룹 생성
+// 예: api := s.Group("/api", auth.Authenticator(), middleware.OPA())
+func (s *Mist) Group(prefix string, handlers ...gin.HandlerFunc) *Group {
+	return &Group{mist: s, group: s.router.Group(prefix, handlers...)}
+}
+
+// Group: 하위 그룹 생성
+func (g *Group) Group(prefix string, handlers ...gin.HandlerFunc) *Group {
+	return &Group{mist: g.mist, group: g.group.Group(prefix, handlers...)}
+}
+
+// BasePath: 그룹의 전체 경로 접두사
+func (g *Group) BasePath() string {
+	return g.group.BasePath()
+}
+
+// Mist: 그룹이 속한 Mist 서버
+func (g *Group) Mist() *Mist {
+	return g.mist
+}
+
+// Limit: 그룹 안 라우트의 본문 크기 제한과 처리 기한 지정 (Mist.Limit 참고)
+// 예: api.Limit(http.MethodPost, "/files", middleware.RouteLimit{BodyBytes: 1 << 30})
+func (g *Group) Limit(method, relativePath string, limit middleware.RouteLimit) {
+	full := path.Join(g.BasePath(), relativePath)
+	// gin과 같이 상대 경로 끝의 "/"는 유지
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(full, "/") {
+		full += "/"
+	}
+	g.mist.Limit(method, full, limit)
+}
+
+func (g *Group) Use(handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.Use(handlers...)
+}
+
+func (g *Group) GET(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.GET(relativePath, handlers...)
+}
+
+func (g *Group) POST(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.POST(relativePath, handlers...)
+}
+
+func (g *Group) PUT(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.PUT(relativePath, handlers...)
+}
+
+func (g *Group) DELETE(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.DELETE(relativePath, handlers...)
+}
+
+func (g *Group) PATCH(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.PATCH(relativePath, handlers...)
+}
+
+func (g *Group) OPTIONS(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.OPTIONS(relativePath, handlers...)
+}
+
+func (g *Group) HEAD(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	return g.group.HEAD(relativePath, handlers...)
+}
@@ -3,35 +3,97 @@ package mist
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"golang.org/x/crypto/acme/autocert"
 
+	"parkjunwoo.com/microstral/pkg/cert"
 	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/health"
+	"parkjunwoo.com/microstral/pkg/listener"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/metrics"
+	"parkjunwoo.com/microstral/pkg/middleware"
 	"parkjunwoo.com/microstral/pkg/mttp"
+	"parkjunwoo.com/microstral/pkg/param"
+	"parkjunwoo.com/microstral/pkg/report"
 	"parkjunwoo.com/microstral/pkg/services"
+	"parkjunwoo.com/microstral/pkg/session"
+	"parkjunwoo.com/microstral/pkg/telemetry"
 )
 
 type Config struct {
-	host      string
-	httpsport int
-	httpport  int
-	fullchain string
-	privkey   string
-	http      bool
-	https     bool
+	host          string
+	httpsport     int
+	httpport      int
+	fullchain     string
+	privkey       string
+	http          bool
+	https         bool
+	httpListener  net.Listener
+	httpsListener net.Listener
+	redisSessions bool
+	tracing       bool
+	metrics       bool
+	metricsPort   int
+	metricsRoute  bool
+
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+
+	httpRedirect bool
+	hsts         bool
+	acmeWebroot  string
+	acme         bool
+
+	h2c       bool
+	http3     bool
+	http3port int
+
+	httpSocket  string
+	httpsSocket string
+	socketMode  string
+
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	maxHeaderBytes    int
+	maxBodyBytes      int64
+	requestTimeout    time.Duration
+
+	defaultVersion string
+}
+
+// Hook은 서버 시작/종료 시 실행되는 함수입니다.
+type Hook func(ctx context.Context) error
+
+// startHook은 시작 훅과, 시작 훅이 성공했을 때만 실행하는 정리 훅입니다.
+type startHook struct {
+	start Hook
+	stop  []Hook
 }
 
 type Mist struct {
@@ -40,114 +102,550 @@ type Mist struct {
 		Close() error
 	}
 	router *gin.Engine
+	store  sessions.Store
 	httpc  *mttp.Client
-	awsCfg aws.Config
-}
+	logger *slog.Logger
+	health *health.Registry
 
-// New: Mist 서버 생성자
-func New(http bool, https bool) (*Mist, error) {
-	httpc := mttp.NewClient()
+	tracerProvider *sdktrace.TracerProvider
+	tlsConfig      *tls.Config
+	acmeManager    *autocert.Manager
+	reporter       report.Reporter
 
-	region := env.GetEnv("REGION", "ap-northeast-2")
-	awsCfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
-	if err != nil {
-		return nil, err
-	}
+	onStart    []startHook
+	onShutdown []Hook
+
+	versions map[string]*apiVersion
+	limits   *middleware.Limits
+
+	sessionKeys    []session.KeyPair
+	sessionOptions *sessions.Options
+
+	awsCfg  *aws.Config
+	awsErr  error
+	awsOnce sync.Once
 
+	redisMu sync.Mutex
+	redis   *redis.Client
+}
+
+// HOST를 설정하지 않았을 때 GetHost가 반환하는 이름
+const defaultHost = "mist"
+
+// New: Mist 서버 생성자
+func New(opts ...Option) (*Mist, error) {
 	s := &Mist{
 		cfg: Config{
-			host:      env.GetEnv("HOST", "mist"),
+			// 설정하지 않으면 비워 두어 리다이렉트는 요청 Host를, ACME는 ACME_HOSTS를 사용
+			host:      env.GetEnv("HOST", ""),
 			httpsport: env.GetEnvInt("HTTPS_PORT", 443),
 			httpport:  env.GetEnvInt("HTTP_PORT", 80),
 			fullchain: env.GetEnv("TLS_FULLCHAIN", ""),
 			privkey:   env.GetEnv("TLS_PRIVKEY", ""),
-			http:      http,
-			https:     https,
+			http:      env.GetEnvBool("HTTP_ENABLED", true),
+			https:     env.GetEnvBool("HTTPS_ENABLED", false),
+
+			redisSessions: env.GetEnv("SESSION_STORE", "cookie") == "redis",
+			tracing:       env.GetEnvBool("TRACING_ENABLED", false),
+			metrics:       env.GetEnvBool("METRICS_ENABLED", false),
+			metricsPort:   env.GetEnvInt("METRICS_PORT", 0),
+			metricsRoute:  env.GetEnvBool("METRICS_ROUTE", false),
+
+			shutdownTimeout: env.GetEnvDuration("SHUTDOWN_TIMEOUT", 5*time.Second),
+			drainDelay:      env.GetEnvDuration("SHUTDOWN_DRAIN_DELAY", 0),
+
+			httpRedirect: env.GetEnvBool("HTTP_REDIRECT", false),
+			hsts:         env.GetEnvBool("HSTS_ENABLED", false),
+			acmeWebroot:  env.GetEnv("ACME_WEBROOT", ""),
+			acme:         env.GetEnvBool("ACME_ENABLED", false),
+
+			h2c:       env.GetEnvBool("H2C_ENABLED", false),
+			http3:     env.GetEnvBool("HTTP3_ENABLED", false),
+			http3port: env.GetEnvInt("HTTP3_PORT", 0),
+
+			httpSocket:  env.GetEnv("HTTP_SOCKET", ""),
+			httpsSocket: env.GetEnv("HTTPS_SOCKET", ""),
+			socketMode:  env.GetEnv("SOCKET_MODE", "0660"),
+
+			readTimeout:       env.GetEnvDuration("HTTP_READ_TIMEOUT", 30*time.Second),
+			readHeaderTimeout: env.GetEnvDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+			writeTimeout:      env.GetEnvDuration("HTTP_WRITE_TIMEOUT", 60*time.Second),
+			idleTimeout:       env.GetEnvDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
+			maxHeaderBytes:    env.GetEnvInt("HTTP_MAX_HEADER_BYTES", 1<<20),
+			maxBodyBytes:      env.GetEnvInt64("HTTP_MAX_BODY_BYTES", 10<<20),
+			requestTimeout:    env.GetEnvDuration("HTTP_REQUEST_TIMEOUT", 0),
+
+			defaultVersion: normalizeVersion(env.GetEnv("API_DEFAULT_VERSION", "")),
 		},
-		router: gin.Default(),
-		httpc:  httpc,
-		awsCfg: awsCfg,
+		httpc:  mttp.NewClient(),
+		health: health.NewRegistry(env.GetEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second)),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
 
-	store := cookie.NewStore([]byte("secret"))
-	s.router.Use(sessions.Sessions("s", store))
+	if s.logger == nil {
+		s.logger = logger.New(os.Stdout)
+	}
+	// 표준 log 패키지와 slog.Default()를 사용하는 라이브러리도 같은 형식으로 출력
+	slog.SetDefault(s.logger)
+
+	// gin binding 태그에서 param 타입(binding:"required,phone_kr")을 사용할 수 있도록 등록
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := param.RegisterValidator(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.reporter == nil {
+		r, err := report.FromEnv()
+		if err != nil {
+			return nil, err
+		}
+		s.reporter = r
+	}
+
+	if s.cfg.tracing && s.tracerProvider == nil {
+		tp, err := telemetry.NewTracerProvider(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		s.tracerProvider = tp
+	}
+	if s.tracerProvider != nil {
+		telemetry.Install(s.tracerProvider)
+	}
+
+	if s.router == nil {
+		s.router = gin.New()
+		if s.tracerProvider != nil {
+			s.router.Use(middleware.Tracing())
+		}
+		if s.cfg.metrics {
+			s.router.Use(middleware.Metrics())
+		}
+		s.router.Use(middleware.Logger(s.logger), middleware.RequestID(), middleware.Recovery(s.reporter))
+	}
+	// gin.Context를 context.Context로 넘겨도 요청 컨텍스트 값(요청 ID 등)을 조회할 수 있도록 설정
+	s.router.ContextWithFallback = true
+	// problem.Abort로 기록된 오류를 application/problem+json으로 렌더링 (이후 미들웨어의 오류 포함)
+	s.router.Use(middleware.Errors(), versionCheck())
+	if s.cfg.https && s.cfg.hsts {
+		s.router.Use(middleware.HSTS())
+	}
+	// 전역 본문 크기 제한과 처리 기한. 라우트별 값은 Limit으로 지정합니다.
+	s.limits = middleware.NewLimits(s.cfg.maxBodyBytes, s.cfg.requestTimeout)
+	s.router.Use(s.limits.Handler())
+	if s.store == nil {
+		store, err := s.newSessionStore()
+		if err != nil {
+			return nil, err
+		}
+		s.store = store
+	}
+	s.router.Use(sessions.Sessions("s", s.store))
+
+	if s.cfg.acme && s.acmeManager == nil {
+		manager, err := s.newACMEManager()
+		if err != nil {
+			return nil, err
+		}
+		s.acmeManager = manager
+	}
 
 	// 헬스체크 엔드포인트
-	s.GET("/healthcheck", nil, services.Healthcheck)
-	s.GET("/live", nil, services.Healthcheck)
+	// /live는 프로세스 생존 여부만, /ready는 등록된 의존성 체크 결과를 응답
+	s.GET("/healthcheck", services.Healthcheck)
+	s.GET("/live", services.Healthcheck)
+	s.GET("/ready", s.health.Handler())
+
+	// 메트릭 엔드포인트
+	// 라우트 템플릿, DB 풀, 인증 지표가 노출되므로 기본은 METRICS_PORT의 별도 관리 포트(Run에서 시작)이고,
+	// 메인 라우터에는 METRICS_ROUTE(WithMetricsRoute)로 명시한 경우에만 등록
+	if s.cfg.metrics && s.cfg.metricsRoute {
+		s.GET("/metrics", gin.WrapH(metrics.Handler()))
+	} else if s.cfg.metrics && s.cfg.metricsPort == 0 {
+		s.log().Warn("metrics are collected but not exposed; set METRICS_PORT or METRICS_ROUTE")
+	}
 
 	return s, nil
 }
 
+// newSessionStore: 세션 키와 쿠키 옵션으로 기본 세션 저장소 생성
+// SESSION_STORE=redis 또는 WithRedisSessions 옵션이면 Redis 저장소를, 아니면 쿠키 저장소를 사용합니다.
+func (s *Mist) newSessionStore() (sessions.Store, error) {
+	keys := s.sessionKeys
+	if len(keys) == 0 {
+		var err error
+		keys, err = session.LoadKeys(context.TODO(), s.GetAWSConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(keys) == 0 {
+		s.log().Warn("no session keys configured (SESSION_KEYS, SESSION_KEYS_FILE, SESSION_SECRET), using random keys")
+		var err error
+		keys, err = session.RandomKeys()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// SESSION_SECURE를 설정하지 않으면 HTTPS 사용 여부에 따라 Secure 쿠키 발급
+	options := session.OptionsFromEnv(s.cfg.https)
+	if s.sessionOptions != nil {
+		options = *s.sessionOptions
+	}
+
+	if s.cfg.redisSessions {
+		conn, err := s.Redis()
+		if err != nil {
+			return nil, err
+		}
+		prefix := env.GetEnv("SESSION_REDIS_PREFIX", "session:")
+		return session.NewRedisStore(conn, keys, options, prefix), nil
+	}
+	return session.NewCookieStore(keys, options), nil
+}
+
 // Run: 서버 실행
+// SIGINT/SIGTERM을 받으면 종료합니다. 다른 방법으로 종료하려면 RunContext를 사용합니다.
 func (s *Mist) Run() error {
-	errCh := make(chan error, 2)
-	var httpsServer, httpServer *http.Server
+	return s.RunContext(context.Background())
+}
+
+// RunContext: ctx가 취소되거나 SIGINT/SIGTERM을 받을 때까지 서버 실행
+// 종료 순서:
+//  1. /ready를 실패로 전환하고 SHUTDOWN_DRAIN_DELAY만큼 대기 (로드밸런서가 트래픽을 끊을 시간)
+//  2. HTTP 서버가 처리 중인 요청을 SHUTDOWN_TIMEOUT 안에 마치도록 대기
+//  3. OnShutdown 훅과 OnStart의 stop 훅을 등록 역순으로 실행
+//  4. Postgres/Redis 등 등록된 연결을 생성 역순으로 닫음
+func (s *Mist) RunContext(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var tlsConfig *tls.Config
+	if s.cfg.https {
+		var err error
+		if tlsConfig, err = s.newTLSConfig(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.listen(); err != nil {
+		s.closeListeners()
+		return err
+	}
+
+	// 성공한 시작 훅의 정리 훅 (시작 실패 시 또는 종료 시 역순으로 실행)
+	var stops []Hook
+	for _, hook := range s.onStart {
+		if err := hook.start(ctx); err != nil {
+			s.closeListeners()
+			stopCtx, cancel := context.WithTimeout(context.Background(), s.cfg.shutdownTimeout)
+			s.runHooks(stopCtx, stops)
+			cancel()
+			return fmt.Errorf("start hook failed: %w", err)
+		}
+		stops = append(stops, hook.stop...)
+	}
+
+	errCh := make(chan error, 4)
+	var servers []*http.Server
+	var h3Server *http3.Server
 
 	if s.cfg.http {
-		httpServer = &http.Server{
-			Addr:    fmt.Sprintf(":%d", s.cfg.httpport),
-			Handler: s.router,
+		httpServer := s.newServer(s.cfg.httpport, s.httpHandler())
+		if s.cfg.h2c {
+			// TLS 없이 HTTP/1.1과 HTTP/2(prior knowledge)를 함께 처리
+			protocols := new(http.Protocols)
+			protocols.SetHTTP1(true)
+			protocols.SetUnencryptedHTTP2(true)
+			httpServer.Protocols = protocols
 		}
+		servers = append(servers, httpServer)
 		go func() {
-			log.Println("Starting HTTP server...")
-			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			var err error
+			if s.cfg.httpListener != nil {
+				s.log().Info("starting HTTP server", "addr", s.cfg.httpListener.Addr().String())
+				err = httpServer.Serve(s.cfg.httpListener)
+			} else {
+				s.log().Info("starting HTTP server", "addr", httpServer.Addr)
+				err = httpServer.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
 				errCh <- err
 			}
 		}()
 	}
 	if s.cfg.https {
-		httpsServer = &http.Server{
-			Addr:    fmt.Sprintf(":%d", s.cfg.httpsport),
-			Handler: s.router,
+		handler := s.Handler()
+		if s.cfg.http3 {
+			port := s.cfg.http3port
+			if port == 0 {
+				port = s.cfg.httpsport
+			}
+			h3Server = &http3.Server{
+				Addr:           fmt.Sprintf(":%d", port),
+				Handler:        handler,
+				TLSConfig:      http3.ConfigureTLSConfig(tlsConfig),
+				IdleTimeout:    s.cfg.idleTimeout,
+				MaxHeaderBytes: s.cfg.maxHeaderBytes,
+			}
+			go func() {
+				s.log().Info("starting HTTP/3 server", "addr", h3Server.Addr)
+				if err := h3Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					errCh <- err
+				}
+			}()
+			// HTTPS 응답에 Alt-Svc 헤더를 추가하여 클라이언트가 HTTP/3로 전환하도록 안내
+			next := handler
+			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h3Server.SetQUICHeaders(w.Header())
+				next.ServeHTTP(w, r)
+			})
 		}
+		httpsServer := s.newServer(s.cfg.httpsport, handler)
+		httpsServer.TLSConfig = tlsConfig
+		servers = append(servers, httpsServer)
+		go func() {
+			var err error
+			if s.cfg.httpsListener != nil {
+				s.log().Info("starting HTTPS server", "addr", s.cfg.httpsListener.Addr().String())
+				err = httpsServer.ServeTLS(s.cfg.httpsListener, "", "")
+			} else {
+				s.log().Info("starting HTTPS server", "addr", httpsServer.Addr)
+				err = httpsServer.ListenAndServeTLS("", "")
+			}
+			if err != nil && err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+	if s.cfg.metrics && s.cfg.metricsPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsServer := s.newServer(s.cfg.metricsPort, mux)
+		servers = append(servers, metricsServer)
 		go func() {
-			log.Println("Starting HTTPS server...")
-			if err := httpsServer.ListenAndServeTLS(s.cfg.fullchain, s.cfg.privkey); err != nil && err != http.ErrServerClosed {
+			s.log().Info("starting metrics server", "addr", metricsServer.Addr)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				errCh <- err
 			}
 		}()
 	}
+
 	// Graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	var runErr error
 	select {
-	case <-quit:
-		log.Println("now shutting down server...")
-	case err := <-errCh:
-		return err
+	case <-ctx.Done():
+		s.log().Info("now shutting down server")
+	case runErr = <-errCh:
+		s.log().Error("server error, shutting down", "error", runErr)
+	}
+
+	s.health.SetDraining(true)
+	if runErr == nil && s.cfg.drainDelay > 0 {
+		s.log().Info("waiting for load balancer to drain", "delay", s.cfg.drainDelay.String())
+		time.Sleep(s.cfg.drainDelay)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.shutdownTimeout)
 	defer cancel()
-	defer func() {
-		for _, conn := range s.conns {
-			if err := conn.Close(); err != nil {
-				log.Println(err)
+
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				s.log().Error("server shutdown error", "addr", server.Addr, "error", err)
 			}
+		}()
+	}
+	if h3Server != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h3Server.Shutdown(shutdownCtx); err != nil {
+				s.log().Error("HTTP/3 shutdown error", "addr", h3Server.Addr, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.runHooks(shutdownCtx, append(append([]Hook{}, s.onShutdown...), stops...))
+
+	for i := len(s.conns) - 1; i >= 0; i-- {
+		if err := s.conns[i].Close(); err != nil {
+			s.log().Error("failed to close connection", "error", err)
 		}
-	}()
+	}
 
-	if s.cfg.http {
-		if httpServer != nil {
-			if err := httpServer.Shutdown(ctx); err != nil {
-				log.Printf("HTTP shutdown error: %v", err)
-			}
+	if s.tracerProvider != nil {
+		if err := s.tracerProvider.Shutdown(shutdownCtx); err != nil {
+			s.log().Error("tracer provider shutdown error", "error", err)
 		}
 	}
-	if s.cfg.https {
-		if httpsServer != nil {
-			if err := httpsServer.Shutdown(ctx); err != nil {
-				log.Printf("HTTPS shutdown error: %v", err)
-			}
+
+	s.log().Info("completed server shutdown")
+	return runErr
+}
+
+// newServer: 타임아웃과 헤더 크기 제한을 적용한 http.Server 생성
+func (s *Mist) newServer(port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           handler,
+		ReadTimeout:       s.cfg.readTimeout,
+		ReadHeaderTimeout: s.cfg.readHeaderTimeout,
+		WriteTimeout:      s.cfg.writeTimeout,
+		IdleTimeout:       s.cfg.idleTimeout,
+		MaxHeaderBytes:    s.cfg.maxHeaderBytes,
+	}
+}
+
+// runHooks: 훅을 등록 역순으로 실행합니다. 오류는 기록만 하고 나머지 훅을 계속 실행합니다.
+func (s *Mist) runHooks(ctx context.Context, hooks []Hook) {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil {
+			s.log().Error("shutdown hook failed", "error", err)
+		}
+	}
+}
+
+// closeListeners: 서버를 시작하지 못했을 때 준비한 리스너(유닉스 소켓, systemd 소켓)를 닫습니다.
+func (s *Mist) closeListeners() {
+	for _, ln := range []net.Listener{s.cfg.httpListener, s.cfg.httpsListener} {
+		if ln == nil {
+			continue
+		}
+		if err := ln.Close(); err != nil {
+			s.log().Warn("failed to close listener", "addr", ln.Addr().String(), "error", err)
+		}
+	}
+}
+
+// listen: 옵션으로 지정한 리스너가 없으면 systemd 소켓 활성화 또는 유닉스 소켓 리스너를 준비합니다.
+// systemd 소켓은 FileDescriptorName이 http/https이면 이름으로, 아니면 HTTP, HTTPS 순서로 배정합니다.
+// 배정되지 않은 서버는 HTTP_SOCKET/HTTPS_SOCKET 유닉스 소켓 또는 TCP 포트에서 대기합니다.
+func (s *Mist) listen() error {
+	inherited, err := listener.Systemd()
+	if err != nil {
+		return err
+	}
+	var unnamed []net.Listener
+	for _, l := range inherited {
+		switch {
+		case l.Name == "http" && s.cfg.httpListener == nil:
+			s.cfg.httpListener = l.Listener
+		case l.Name == "https" && s.cfg.httpsListener == nil:
+			s.cfg.httpsListener = l.Listener
+		default:
+			unnamed = append(unnamed, l.Listener)
 		}
 	}
+	if s.cfg.http && s.cfg.httpListener == nil && len(unnamed) > 0 {
+		s.cfg.httpListener, unnamed = unnamed[0], unnamed[1:]
+	}
+	if s.cfg.https && s.cfg.httpsListener == nil && len(unnamed) > 0 {
+		s.cfg.httpsListener, unnamed = unnamed[0], unnamed[1:]
+	}
+	for _, ln := range unnamed {
+		s.log().Warn("unused systemd socket", "addr", ln.Addr().String())
+		ln.Close()
+	}
 
-	log.Println("completed server shutdown")
+	mode, err := listener.ParseMode(s.cfg.socketMode)
+	if err != nil {
+		return err
+	}
+	if s.cfg.http && s.cfg.httpListener == nil && s.cfg.httpSocket != "" {
+		if s.cfg.httpListener, err = listener.Unix(s.cfg.httpSocket, mode); err != nil {
+			return err
+		}
+	}
+	if s.cfg.https && s.cfg.httpsListener == nil && s.cfg.httpsSocket != "" {
+		if s.cfg.httpsListener, err = listener.Unix(s.cfg.httpsSocket, mode); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// httpHandler: HTTP 서버 핸들러
+// 리다이렉트 모드(HTTP_REDIRECT)이면 ACME 챌린지 경로 외의 모든 요청을 HTTPS로 리다이렉트합니다.
+func (s *Mist) httpHandler() http.Handler {
+	var challenge http.Handler
+	switch {
+	case s.acmeManager != nil:
+		challenge = s.acmeManager.HTTPHandler(nil)
+	case s.cfg.acmeWebroot != "":
+		challenge = middleware.ACMEWebroot(s.cfg.acmeWebroot)
+	}
+
+	if s.cfg.httpRedirect {
+		return middleware.HTTPSRedirect(s.cfg.host, s.cfg.httpsport, challenge)
+	}
+	if challenge != nil {
+		return middleware.ACMEChallenge(challenge, s.Handler())
+	}
+	return s.Handler()
+}
+
+// newACMEManager: ACME 인증서 관리자 생성
+// ACME_CACHE가 redis이면 Redis에, 아니면 ACME_CACHE_DIR 디렉터리에 인증서를 저장합니다.
+func (s *Mist) newACMEManager() (*autocert.Manager, error) {
+	var cache autocert.Cache
+	switch env.GetEnv("ACME_CACHE", "dir") {
+	case "redis":
+		conn, err := s.Redis()
+		if err != nil {
+			return nil, err
+		}
+		cache = &cert.RedisCache{Client: conn, Prefix: env.GetEnv("ACME_REDIS_PREFIX", "acme:")}
+	case "dir":
+		cache = cert.DirCache(env.GetEnv("ACME_CACHE_DIR", "acme-cache"))
+	default:
+		return nil, fmt.Errorf("unsupported ACME cache: %s", env.GetEnv("ACME_CACHE", ""))
+	}
+	return cert.NewACMEManager(cache, s.cfg.host)
+}
+
+// newTLSConfig: HTTPS 서버용 TLS 설정 생성
+// WithTLSConfig로 주입하지 않았고 ACME도 사용하지 않으면 TLS_FULLCHAIN/TLS_PRIVKEY 파일을 감시하여 인증서가 갱신되면 재시작 없이 반영합니다.
+// 파일 감시는 ctx가 끝나면 멈춥니다.
+func (s *Mist) newTLSConfig(ctx context.Context) (*tls.Config, error) {
+	if s.tlsConfig != nil {
+		return s.tlsConfig, nil
+	}
+	if s.acmeManager != nil {
+		return cert.ACMEConfigFromEnv(s.acmeManager)
+	}
+	reloader, err := cert.NewReloader(ctx, s.cfg.fullchain, s.cfg.privkey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	return cert.ConfigFromEnv(reloader.GetCertificate)
+}
+
+// OnStart: 서버 시작 전에 실행할 훅 등록 (등록 순서대로 실행)
+// stop은 hook이 성공한 경우에만 종료 시 OnShutdown 훅과 함께 등록 역순으로 실행합니다.
+// 훅이 오류를 반환하면 서버를 시작하지 않고, 준비한 리스너를 닫고 앞서 성공한 훅의 stop을 실행한 뒤 RunContext가 오류를 반환합니다.
+func (s *Mist) OnStart(hook Hook, stop ...Hook) {
+	s.onStart = append(s.onStart, startHook{start: hook, stop: stop})
+}
+
+// OnShutdown: HTTP 서버가 요청 처리를 마친 뒤, 연결을 닫기 전에 실행할 훅 등록
+// 나중에 등록한 훅부터 역순으로 실행하며, ctx에는 SHUTDOWN_TIMEOUT 기한이 걸려 있습니다.
+func (s *Mist) OnShutdown(hook Hook) {
+	s.onShutdown = append(s.onShutdown, hook)
+}
+
+// GetHost: 서버 호스트 이름 (HOST가 없으면 "mist")
 func (s *Mist) GetHost() string {
+	if s.cfg.host == "" {
+		return defaultHost
+	}
 	return s.cfg.host
 }
 
@@ -163,10 +661,68 @@ func (s *Mist) GetRouter() *gin.Engine {
 	return s.router
 }
 
+// Handler: 서버가 사용하는 http.Handler
+// 엔진에 Accept-Version 헤더 라우팅을 더한 것으로, httptest 등에서 GetRouter 대신 사용합니다.
+func (s *Mist) Handler() http.Handler {
+	return s.versionRouter(s.router)
+}
+
 func (s *Mist) GetHTTP() *mttp.Client {
 	return s.httpc
 }
 
+func (s *Mist) GetLogger() *slog.Logger {
+	return s.logger
+}
+
+// log: Mist 컴포넌트 로거
+func (s *Mist) log() *slog.Logger {
+	return logger.Component(s.logger, "mist")
+}
+
+// GetHealth: readiness 체크 레지스트리
+func (s *Mist) GetHealth() *health.Registry {
+	return s.health
+}
+
+// AddCheck: /ready에서 실행할 readiness 체크 등록
+// 예: s.AddCheck("cognito-jwks", cognito.JWKSCheck()), s.AddCheck("opa", middleware.OPAPolicyLoaded)
+func (s *Mist) AddCheck(name string, check health.Check) {
+	s.health.Add(name, check)
+}
+
+func (s *Mist) GetSessionStore() sessions.Store {
+	return s.store
+}
+
+// GetAWSConfig: WithAWSConfig로 주입된 설정을 반환하거나,
+// 없으면 최초 호출 시 기본 자격 증명 체인(REGION)으로 로드합니다.
+func (s *Mist) GetAWSConfig() (aws.Config, error) {
+	s.awsOnce.Do(func() {
+		if s.awsCfg != nil {
+			return
+		}
+		region := env.GetEnv("REGION", "ap-northeast-2")
+		awsCfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
+		if err != nil {
+			s.awsErr = err
+			return
+		}
+		s.awsCfg = &awsCfg
+	})
+	if s.awsErr != nil {
+		return aws.Config{}, s.awsErr
+	}
+	return *s.awsCfg, nil
+}
+
+// Limit: 라우트의 본문 크기 제한과 처리 기한을 전역 값(HTTP_MAX_BODY_BYTES, HTTP_REQUEST_TIMEOUT) 대신 지정
+// path는 라우트 전체 경로(예: "/v1/files/:id")이며, RouteLimit의 0 값은 전역 값을, 음수는 제한 없음을 뜻합니다.
+// 예: s.Limit(http.MethodPost, "/upload", middleware.RouteLimit{BodyBytes: 1 << 30, Timeout: 10 * time.Minute})
+func (s *Mist) Limit(method, path string, limit middleware.RouteLimit) {
+	s.limits.Set(method, path, limit)
+}
+
 func (s *Mist) Use(handlers ...gin.HandlerFunc) gin.IRoutes {
 	return s.router.Use(handlers...)
 }
@@ -212,7 +768,8 @@ func (s *Mist) Postgres() (*sql.DB, error) {
 
 	postgresDSN := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		host, port, username, password, dbname)
-	conn, err := sql.Open("postgres", postgresDSN)
+	// 전역 TracerProvider가 등록되어 있으면 쿼리마다 스팬을 기록
+	conn, err := otelsql.Open("postgres", postgresDSN, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, err
 	}
@@ -225,15 +782,31 @@ func (s *Mist) Postgres() (*sql.DB, error) {
 	// Postgres 연결 테스트
 	err = conn.PingContext(context.Background())
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
+	// 연결 풀 통계를 메트릭으로 노출
+	if err := metrics.RegisterDB(dbname, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.health.Add("postgres", health.Ping(conn))
+
 	s.conns = append(s.conns, conn)
 
 	return conn, nil
 }
 
+// Redis: Redis 클라이언트 반환
+// 처음 성공한 호출에서 만든 클라이언트를 재사용하므로 세션 저장소와 애플리케이션이 같은 연결 풀을 공유합니다.
 func (s *Mist) Redis() (*redis.Client, error) {
+	s.redisMu.Lock()
+	defer s.redisMu.Unlock()
+	if s.redis != nil {
+		return s.redis, nil
+	}
+
 	//REDIS 연결
 	host := env.GetEnv("REDIS_HOST", "redis")
 	port := env.GetEnvInt("REDIS_PORT", 6379)
@@ -250,13 +823,30 @@ func (s *Mist) Redis() (*redis.Client, error) {
 		MinIdleConns: minIdleConns,
 	})
 
+	// 전역 TracerProvider가 등록되어 있으면 명령마다 스팬을 기록
+	if err := redisotel.InstrumentTracing(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
 	// REIDS 연결 테스트
 	_, err := conn.Ping(context.Background()).Result()
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
+	// 연결 풀 통계를 메트릭으로 노출
+	if err := metrics.RegisterRedis(fmt.Sprintf("%s:%d/%d", host, port, db), conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.health.Add("redis", func(ctx context.Context) error {
+		return conn.Ping(ctx).Err()
+	})
+
 	s.conns = append(s.conns, conn)
+	s.redis = conn
 
 	return conn, nil
 }
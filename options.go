@@ -0,0 +1,314 @@
+// parkjunwoo.com/microstral/options.go
+package mist
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/crypto/acme/autocert"
+
+	"parkjunwoo.com/microstral/pkg/report"
+	"parkjunwoo.com/microstral/pkg/session"
+)
+
+// Option은 New에 전달되어 Mist 설정을 변경합니다.
+// 옵션으로 지정하지 않은 값은 환경 변수 기본값을 사용합니다.
+type Option func(*Mist)
+
+// WithHost: 서버 호스트 이름 (기본값: HOST)
+// HTTPS 리다이렉트 대상과 ACME 기본 도메인으로 사용하며, 비어 있으면 리다이렉트는 요청의 Host를 사용합니다.
+func WithHost(host string) Option {
+	return func(s *Mist) {
+		s.cfg.host = host
+	}
+}
+
+// WithHTTP: HTTP 서버 사용 여부 (기본값: HTTP_ENABLED)
+func WithHTTP(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.http = enabled
+	}
+}
+
+// WithHTTPS: HTTPS 서버 사용 여부 (기본값: HTTPS_ENABLED)
+func WithHTTPS(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.https = enabled
+	}
+}
+
+// WithHTTPPort: HTTP 포트 (기본값: HTTP_PORT)
+func WithHTTPPort(port int) Option {
+	return func(s *Mist) {
+		s.cfg.httpport = port
+	}
+}
+
+// WithHTTPSPort: HTTPS 포트 (기본값: HTTPS_PORT)
+func WithHTTPSPort(port int) Option {
+	return func(s *Mist) {
+		s.cfg.httpsport = port
+	}
+}
+
+// WithTLSFiles: 인증서 체인과 개인키 파일 경로 (기본값: TLS_FULLCHAIN, TLS_PRIVKEY)
+func WithTLSFiles(fullchain string, privkey string) Option {
+	return func(s *Mist) {
+		s.cfg.fullchain = fullchain
+		s.cfg.privkey = privkey
+	}
+}
+
+// WithHTTPListener: 포트 대신 주어진 리스너로 HTTP 서버를 실행합니다.
+func WithHTTPListener(ln net.Listener) Option {
+	return func(s *Mist) {
+		s.cfg.http = true
+		s.cfg.httpListener = ln
+	}
+}
+
+// WithHTTPSListener: 포트 대신 주어진 리스너로 HTTPS 서버를 실행합니다.
+func WithHTTPSListener(ln net.Listener) Option {
+	return func(s *Mist) {
+		s.cfg.https = true
+		s.cfg.httpsListener = ln
+	}
+}
+
+// WithLogger: 환경 변수(LOG_LEVEL, LOG_FORMAT, LOG_LEVELS) 기반 JSON 로거 대신 사용할 로거
+func WithLogger(l *slog.Logger) Option {
+	return func(s *Mist) {
+		s.logger = l
+	}
+}
+
+// WithEngine: 기본 gin 엔진(Recovery, 접근 로그, 요청 ID 미들웨어 포함) 대신 사용할 gin 엔진
+func WithEngine(engine *gin.Engine) Option {
+	return func(s *Mist) {
+		s.router = engine
+	}
+}
+
+// WithReporter: 복구한 패닉을 전달할 오류 리포터 (기본값: ERROR_REPORTER, SENTRY_DSN)
+func WithReporter(r report.Reporter) Option {
+	return func(s *Mist) {
+		s.reporter = r
+	}
+}
+
+// WithAWSConfig: 기본 자격 증명 체인 대신 사용할 AWS 설정
+func WithAWSConfig(awsCfg aws.Config) Option {
+	return func(s *Mist) {
+		s.awsCfg = &awsCfg
+	}
+}
+
+// WithSessionStore: 기본 쿠키 세션 저장소 대신 사용할 세션 저장소
+func WithSessionStore(store sessions.Store) Option {
+	return func(s *Mist) {
+		s.store = store
+	}
+}
+
+// WithSessionKeys: 쿠키 세션 서명/암호화 키 (기본값: SESSION_KEYS, SESSION_KEYS_FILE, SESSION_SECRET)
+// 첫 번째 키 쌍으로 서명하고, 나머지 키 쌍은 기존 세션 검증에만 사용합니다.
+func WithSessionKeys(keys ...session.KeyPair) Option {
+	return func(s *Mist) {
+		s.sessionKeys = keys
+	}
+}
+
+// WithSessionOptions: 세션 쿠키 옵션 (기본값: SESSION_* 환경 변수)
+// SESSION_SECURE를 설정하지 않으면 HTTPS를 사용할 때만 Secure 쿠키를 발급합니다.
+func WithSessionOptions(options sessions.Options) Option {
+	return func(s *Mist) {
+		s.sessionOptions = &options
+	}
+}
+
+// WithRedisSessions: 쿠키 대신 Redis에 세션을 저장합니다. (기본값: SESSION_STORE=redis)
+// Redis 연결은 Mist.Redis()로 생성되며, 쿠키에는 서명된 세션 ID만 저장됩니다.
+func WithRedisSessions() Option {
+	return func(s *Mist) {
+		s.cfg.redisSessions = true
+	}
+}
+
+// WithTracing: OpenTelemetry 트레이싱을 사용합니다. (기본값: TRACING_ENABLED)
+// TracerProvider는 OTEL_* 환경 변수로 설정한 OTLP 익스포터로 생성됩니다.
+func WithTracing() Option {
+	return func(s *Mist) {
+		s.cfg.tracing = true
+	}
+}
+
+// WithTracerProvider: 주어진 TracerProvider로 트레이싱을 사용합니다.
+// 테스트에서는 sdktrace.WithSyncer(tracetest.NewInMemoryExporter())로 만든 TracerProvider와 함께 사용합니다.
+func WithTracerProvider(tp *sdktrace.TracerProvider) Option {
+	return func(s *Mist) {
+		s.tracerProvider = tp
+	}
+}
+
+// WithMetrics: Prometheus 메트릭 수집 및 /metrics 엔드포인트 사용 여부 (기본값: METRICS_ENABLED, false)
+// /metrics는 WithMetricsPort의 별도 포트나 WithMetricsRoute로 노출합니다.
+func WithMetrics(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.metrics = enabled
+	}
+}
+
+// WithMetricsPort: /metrics를 노출할 별도 관리 포트 (기본값: METRICS_PORT, 0이면 사용 안 함)
+func WithMetricsPort(port int) Option {
+	return func(s *Mist) {
+		s.cfg.metricsPort = port
+	}
+}
+
+// WithMetricsRoute: 메인 라우터에 /metrics 등록 여부 (기본값: METRICS_ROUTE, false)
+// 공개 포트로 내부 지표가 노출되므로 인증 프록시 뒤에 있거나 내부 전용 서비스일 때만 사용합니다.
+func WithMetricsRoute(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.metricsRoute = enabled
+	}
+}
+
+// WithShutdownTimeout: 종료 시 처리 중인 요청과 OnShutdown 훅을 기다리는 최대 시간 (기본값: SHUTDOWN_TIMEOUT, 5s)
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Mist) {
+		s.cfg.shutdownTimeout = timeout
+	}
+}
+
+// WithDrainDelay: /ready를 실패로 전환한 뒤 서버 종료를 시작하기까지 대기 시간 (기본값: SHUTDOWN_DRAIN_DELAY, 0)
+// 로드밸런서의 readiness 확인 주기보다 길게 설정하면 종료 중인 인스턴스로 새 요청이 가지 않습니다.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(s *Mist) {
+		s.cfg.drainDelay = delay
+	}
+}
+
+// WithTLSConfig: HTTPS 서버의 TLS 설정을 직접 지정합니다.
+// 지정하지 않으면 TLS_FULLCHAIN/TLS_PRIVKEY를 감시하는 설정을 TLS_* 환경 변수로 생성합니다.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Mist) {
+		s.tlsConfig = cfg
+	}
+}
+
+// WithHTTPRedirect: HTTP 서버를 HTTPS 리다이렉트 전용으로 사용 (기본값: HTTP_REDIRECT)
+// 리다이렉트 주소는 HOST와 HTTPS_PORT로 만들며, ACME_WEBROOT가 있으면 ACME 챌린지 파일은 그대로 응답합니다.
+func WithHTTPRedirect(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.httpRedirect = enabled
+	}
+}
+
+// WithHSTS: HTTPS 응답에 Strict-Transport-Security 헤더 추가 (기본값: HSTS_ENABLED)
+// 헤더 값은 HSTS_MAX_AGE, HSTS_INCLUDE_SUBDOMAINS, HSTS_PRELOAD로 설정합니다.
+func WithHSTS(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.hsts = enabled
+	}
+}
+
+// WithACME: TLS_FULLCHAIN/TLS_PRIVKEY 대신 ACME(Let's Encrypt)로 인증서를 발급/갱신 (기본값: ACME_ENABLED)
+// 호스트 목록과 캐시 위치는 ACME_HOSTS, ACME_CACHE, ACME_CACHE_DIR 등으로 설정합니다.
+// 캐시를 공유하는 복제본끼리 발급을 조율하지 않으므로 첫 발급은 복제본 하나로 받아 두십시오 (cert.DirCache 참고).
+func WithACME(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.acme = enabled
+	}
+}
+
+// WithACMEManager: 직접 구성한 ACME 인증서 관리자를 사용합니다.
+func WithACMEManager(m *autocert.Manager) Option {
+	return func(s *Mist) {
+		s.acmeManager = m
+	}
+}
+
+// WithH2C: HTTP 포트에서 TLS 없는 HTTP/2(h2c)도 처리 (기본값: H2C_ENABLED)
+// 메시 내부의 서비스 간 HTTP/2 트래픽용입니다.
+func WithH2C(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.h2c = enabled
+	}
+}
+
+// WithHTTP3: HTTPS와 함께 QUIC/HTTP3 서버를 실행하고 Alt-Svc 헤더로 안내 (기본값: HTTP3_ENABLED)
+func WithHTTP3(enabled bool) Option {
+	return func(s *Mist) {
+		s.cfg.http3 = enabled
+	}
+}
+
+// WithHTTP3Port: HTTP/3 UDP 포트 (기본값: HTTP3_PORT, 0이면 HTTPS 포트)
+func WithHTTP3Port(port int) Option {
+	return func(s *Mist) {
+		s.cfg.http3port = port
+	}
+}
+
+// WithHTTPSocket: HTTP 서버를 TCP 포트 대신 유닉스 도메인 소켓에서 실행 (기본값: HTTP_SOCKET)
+// 소켓 파일 권한은 SOCKET_MODE(기본값 0660)를 따릅니다.
+func WithHTTPSocket(path string) Option {
+	return func(s *Mist) {
+		s.cfg.httpSocket = path
+	}
+}
+
+// WithHTTPSSocket: HTTPS 서버를 TCP 포트 대신 유닉스 도메인 소켓에서 실행 (기본값: HTTPS_SOCKET)
+func WithHTTPSSocket(path string) Option {
+	return func(s *Mist) {
+		s.cfg.httpsSocket = path
+	}
+}
+
+// WithTimeouts: HTTP 서버 타임아웃 (기본값: HTTP_READ_TIMEOUT 30s, HTTP_READ_HEADER_TIMEOUT 10s,
+// HTTP_WRITE_TIMEOUT 60s, HTTP_IDLE_TIMEOUT 120s)
+// 0은 제한 없음입니다. 느린 헤더 전송(slowloris)을 막으려면 readHeader를 0으로 두지 마세요.
+func WithTimeouts(read, readHeader, write, idle time.Duration) Option {
+	return func(s *Mist) {
+		s.cfg.readTimeout = read
+		s.cfg.readHeaderTimeout = readHeader
+		s.cfg.writeTimeout = write
+		s.cfg.idleTimeout = idle
+	}
+}
+
+// WithMaxHeaderBytes: 요청 헤더 최대 크기 (기본값: HTTP_MAX_HEADER_BYTES, 1MB)
+func WithMaxHeaderBytes(n int) Option {
+	return func(s *Mist) {
+		s.cfg.maxHeaderBytes = n
+	}
+}
+
+// WithMaxBodyBytes: 전역 요청 본문 최대 크기, 넘으면 413 (기본값: HTTP_MAX_BODY_BYTES, 10MB / 0이면 제한 없음)
+// 라우트별로 다르게 하려면 Mist.Limit 또는 Group.Limit을 사용합니다.
+func WithMaxBodyBytes(n int64) Option {
+	return func(s *Mist) {
+		s.cfg.maxBodyBytes = n
+	}
+}
+
+// WithRequestTimeout: 전역 핸들러 타임아웃, 지나면 요청 컨텍스트를 취소 (기본값: HTTP_REQUEST_TIMEOUT, 0이면 제한 없음)
+// 라우트별로 다르게 하려면 Mist.Limit 또는 Group.Limit을 사용합니다.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(s *Mist) {
+		s.cfg.requestTimeout = timeout
+	}
+}
+
+// WithDefaultVersion: 경로와 Accept-Version 헤더에 버전이 없는 요청을 보낼 API 버전 (기본값: API_DEFAULT_VERSION)
+func WithDefaultVersion(name string) Option {
+	return func(s *Mist) {
+		s.cfg.defaultVersion = normalizeVersion(name)
+	}
+}
@@ -4,9 +4,11 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/MicahParks/keyfunc/v3"
@@ -19,6 +21,9 @@ import (
 	"github.com/lib/pq"
 
 	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/health"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/metrics"
 	"parkjunwoo.com/microstral/pkg/secure"
 )
 
@@ -32,6 +37,7 @@ type CognitoModel struct {
 	SigninCallbackURI  string
 	SignoutCallbackURI string
 	JWKS               keyfunc.Keyfunc
+	JWKSURL            string
 
 	TokenExpiresIn   int
 	IDExpiresIn      int
@@ -49,14 +55,14 @@ func NewCognitoModel(awsCfg aws.Config) *CognitoModel {
 		SecretId: &clientSecretName,
 	})
 	if err != nil {
-		log.Fatalf("unable to retrieve secret %s: %w", clientSecretName, err)
-		return nil
+		logger.Component(slog.Default(), "auth").Error("unable to retrieve secret", "secret", clientSecretName, "error", err)
+		os.Exit(1)
 	}
 	// JWKS 인스턴스 생성
 	keyfunc, err := keyfunc.NewDefault([]string{jwksURL})
 	if err != nil {
-		log.Fatalf("failed to create JWKS keyfunc: %v", err)
-		return nil
+		logger.Component(slog.Default(), "auth").Error("failed to create JWKS keyfunc", "error", err)
+		os.Exit(1)
 	}
 	// CognitoModel 인스턴스 생성
 	return &CognitoModel{
@@ -69,6 +75,7 @@ func NewCognitoModel(awsCfg aws.Config) *CognitoModel {
 		SigninCallbackURI:  env.GetEnv("AUTH_SIGNIN_CALLBACK", ""),
 		SignoutCallbackURI: env.GetEnv("AUTH_SIGNOUT_CALLBACK", ""),
 		JWKS:               keyfunc,
+		JWKSURL:            jwksURL,
 
 		TokenExpiresIn:   env.GetEnvInt("AUTH_TOKEN_EXPIRES_IN", 3600),          // 기본 1시간
 		IDExpiresIn:      env.GetEnvInt("AUTH_ID_EXPIRES_IN", 3600),             // 기본 1시간
@@ -76,6 +83,11 @@ func NewCognitoModel(awsCfg aws.Config) *CognitoModel {
 	}
 }
 
+// JWKSCheck는 Cognito JWKS 엔드포인트에 접근 가능한지 확인하는 readiness 체크입니다.
+func (m *CognitoModel) JWKSCheck() health.Check {
+	return health.HTTP(nil, m.JWKSURL)
+}
+
 // Authenticator 미들웨어, 요청의 JWT 토큰을 검증하고 claims를 설정합니다.
 func (m *CognitoModel) Authenticator() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -97,7 +109,10 @@ func (m *CognitoModel) Authenticator() gin.HandlerFunc {
 			refreshToken, err := c.Cookie("r")
 			if err == nil && refreshToken != "" {
 				newTokenRes, err := m.RefreshToken(c.Request.Context(), refreshToken)
-				if err == nil {
+				if err != nil {
+					metrics.TokenRefreshes.WithLabelValues("failure").Inc()
+				} else {
+					metrics.TokenRefreshes.WithLabelValues("success").Inc()
 					// 새 토큰 쿠키 재설정
 					c.SetCookie("t", newTokenRes.IDToken, m.IDExpiresIn, "/", m.Host, true, true)
 					c.SetCookie("r", newTokenRes.RefreshToken, m.RefreshExpiresIn, "/", m.Host, true, true)
@@ -139,6 +154,9 @@ func (m *CognitoModel) Authenticator() gin.HandlerFunc {
 			return
 		}
 		c.Set("claims", claims)
+		// 요청 범위 로거에 사용자 ID 추가
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(logger.NewContext(ctx, logger.FromContext(ctx).With("user_id", claims.ID)))
 		c.Next()
 	}
 }
@@ -347,6 +365,10 @@ func (m *CognitoModel) GetUser(ctx context.Context, id string) (*UsersItem, erro
 
 	resp, err := m.Client.AdminGetUser(ctx, input)
 	if err != nil {
+		var notFound *types.UserNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+		}
 		return nil, err
 	}
 
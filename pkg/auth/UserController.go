@@ -3,20 +3,24 @@ package auth
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"parkjunwoo.com/microstral/pkg/cloudfront"
 	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/logger"
 	"parkjunwoo.com/microstral/pkg/param"
+	"parkjunwoo.com/microstral/pkg/problem"
+	"parkjunwoo.com/microstral/pkg/session"
 )
 
 type UserController struct {
@@ -63,6 +67,11 @@ func GetClaims(c *gin.Context) *Claims {
 	return &Claims{Groups: []string{"Guest"}}
 }
 
+// requestLogger: 요청 범위 auth 컴포넌트 로거
+func requestLogger(c *gin.Context) *slog.Logger {
+	return logger.Component(logger.FromContext(c.Request.Context()), "auth")
+}
+
 func generateState() string {
 	b := make([]byte, 32)
 	_, _ = rand.Read(b)
@@ -82,28 +91,55 @@ func (ctrl *UserController) Signin(c *gin.Context) {
 
 // OAuth2 로그인 콜백 핸들러
 func (ctrl *UserController) SigninCallback(c *gin.Context) {
+	log := requestLogger(c)
+
 	state := c.Query("state")
-	session := sessions.Default(c)
-	expectedState := session.Get("oauth_state")
-	if expectedState != state {
-		c.AbortWithStatus(http.StatusForbidden)
+	sess := sessions.Default(c)
+	expectedState, _ := sess.Get("oauth_state").(string)
+	// 로그인 전 세션 ID를 그대로 쓰지 않도록 새 세션으로 교체 (세션 고정 방지)
+	// 기존 값이 모두 지워지므로 state도 한 번만 사용할 수 있음. 세션은 응답마다 한 번만 저장
+	if err := session.Regenerate(c.Request.Context(), sess); err != nil {
+		log.Warn("failed to revoke previous session", "error", err)
+	}
+	fail := func(err error) {
+		sess.Save()
+		problem.Abort(c, err)
+	}
+	if state == "" || expectedState == "" ||
+		subtle.ConstantTimeCompare([]byte(expectedState), []byte(state)) != 1 {
+		log.Warn("invalid oauth state")
+		fail(problem.Forbidden("invalid_state", "invalid or expired OAuth state"))
 		return
 	}
 
 	code := c.Query("code")
 	if code == "" {
-		log.Printf("[WARN] no authorization code provided in callback")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "no authorization code provided"})
+		log.Warn("no authorization code provided in callback")
+		fail(problem.Invalid("code", "required", "authorization code is required"))
 		return
 	}
 
 	tokenRes, err := ctrl.AuthModel.GetToken(c.Request.Context(), code)
 	if err != nil {
-		log.Printf("[ERROR] failed to get token: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to get token", "error", err)
+		fail(problem.Internal(err))
 		return
 	}
 
+	// 세션에 사용자 ID 기록 (Redis 세션 저장소의 사용자별 세션 폐기에 사용)
+	// ID 토큰은 GetToken에서 이미 검증되었으므로 서명 검증 없이 claims만 읽음
+	token, _, err := jwt.NewParser().ParseUnverified(tokenRes.IDToken, jwt.MapClaims{})
+	if err == nil {
+		if mapClaims, ok := token.Claims.(jwt.MapClaims); ok {
+			sess.Set(session.UserIDKey, parseClaims(mapClaims).ID)
+		}
+	} else {
+		log.Warn("failed to read ID token claims", "error", err)
+	}
+	if err := sess.Save(); err != nil {
+		log.Error("failed to save session", "error", err)
+	}
+
 	// 쿠키 설정
 	c.SetCookie("t", tokenRes.IDToken, ctrl.IDExpiresIn, "/", ctrl.Servername, true, true)
 	c.SetCookie("r", tokenRes.RefreshToken, ctrl.RefreshExpiresIn, "/", ctrl.Servername, true, true)
@@ -130,6 +166,9 @@ func (ctrl *UserController) SigninCallback(c *gin.Context) {
 
 // OAuth2 로그아웃 핸들러
 func (ctrl *UserController) Signout(c *gin.Context) {
+	// 서버 세션 삭제 (Redis 세션 저장소이면 모든 복제본에서 무효화)
+	session.Expire(sessions.Default(c))
+
 	c.SetCookie("t", "", -1, "/", ctrl.Servername, true, true)
 	c.SetCookie("r", "", -1, "/", ctrl.Servername, true, true)
 	c.SetCookie("CloudFront-Key-Pair-Id", "", -1, "/", ctrl.Servername, true, true)
@@ -145,33 +184,19 @@ func (ctrl *UserController) SignoutCallback(c *gin.Context) {
 
 // 비밀번호 초기화 요청 핸들러
 func (ctrl *UserController) PostForgot(c *gin.Context) {
+	log := requestLogger(c)
+
 	var req ForgotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[WARN] failed to parse request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		log.Warn("failed to parse request body", "error", err)
+		problem.Abort(c, problem.BadRequest(problem.CodeInvalidRequest, "request body is not valid JSON"))
 		return
 	}
 	// 이메일 검증
-	email := req.Email
-	if email == "" {
-		log.Printf("[WARN] email is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if len(email) > 200 {
-		log.Printf("[WARN] email too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validEmail, err := param.ValidEmail(email)
+	email, err := forgotEmailParam.Bind(req.Email)
 	if err != nil {
-		log.Printf("[WARN] email error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validEmail {
-		log.Printf("[WARN] email is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		log.Warn("invalid email", "error", err)
+		problem.Abort(c, err)
 		return
 	}
 
@@ -179,13 +204,13 @@ func (ctrl *UserController) PostForgot(c *gin.Context) {
 	// 비밀번호 초기화 요청 처리
 	ok, err := ctrl.AuthModel.PostForgot(ctx, email)
 	if err != nil {
-		log.Printf("[ERROR] failed to request forgot: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to request forgot", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
 	if !ok {
-		log.Printf("[WARN] forgot request failed for email: %s", email)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to request forgot"})
+		log.Warn("forgot request failed", "email", email)
+		problem.Abort(c, problem.BadRequest("forgot_failed", "password reset request was rejected"))
 		return
 	}
 
@@ -206,89 +231,39 @@ func (ctrl *UserController) GetMyinfo(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
-var allowedOrderColumns = map[string]string{
-	"created_at": "created_at",
-	"name":       "name",
-}
-
 // GetUsers: 사용자 목록 조회 (Admin용)
 func (ctrl *UserController) GetUsers(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "60")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		log.Printf("[WARN] invalid limit: %q (%v)", limitStr, err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-
-	pageStr := c.DefaultQuery("page", "1")
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page <= 0 {
-		log.Printf("[WARN] invalid page: %q (%v)", pageStr, err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-
-	order := c.DefaultQuery("order", "created_at")
-	if _, exists := allowedOrderColumns[order]; !exists {
-		log.Printf("[WARN] invalid order: %q", order)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
+	log := requestLogger(c)
 
-	desc := strings.ToUpper(c.DefaultQuery("desc", "DESC"))
-	if desc != "ASC" && desc != "DESC" {
-		log.Printf("[WARN] invalid desc: %q", desc)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	values, err := getUsersSchema.Bind(c)
+	if err != nil {
+		log.Warn("invalid request", "error", err)
+		problem.Abort(c, err)
 		return
 	}
-
-	search := c.DefaultQuery("search", "")
-	if search != "" {
-		valid, err := param.ValidTitleKR(search)
-		if err != nil {
-			log.Printf("[WARN] search validation error: %q (%v)", search, err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-			return
-		}
-		if !valid {
-			log.Printf("[WARN] invalid search value: %q", search)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-			return
-		}
-	}
+	limit, page := values.Int("limit"), values.Int("page")
+	order, desc := values.String("order"), values.String("desc")
+	search, group := values.String("search"), values.String("group")
 
 	ctx := c.Request.Context()
-	group := c.DefaultQuery("group", "")
 	if group != "" {
-		valid, err := param.ValidId(group)
-		if err != nil {
-			log.Printf("[WARN] group validation failed: %q (%v)", group, err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-			return
-		}
-		if !valid {
-			log.Printf("[WARN] invalid group value: %q", group)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-			return
-		}
 		exists, err := ctrl.GroupModel.Exists(ctx, group)
 		if err != nil {
-			log.Printf("[WARN] error checking group existence: %q (%v)", group, err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			log.Warn("error checking group existence", "group", group, "error", err)
+			problem.Abort(c, problem.Internal(err))
 			return
 		}
 		if !exists {
-			log.Printf("[WARN] group does not exist: %q", group)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			log.Warn("group does not exist", "group", group)
+			problem.Abort(c, problem.Invalid("group", "not_found", "group does not exist"))
 			return
 		}
 	}
 
 	result, err := ctrl.UserModel.GetUsers(ctx, limit, page, order, desc, search, group)
 	if err != nil {
-		log.Printf("[ERROR] failed to get articles: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to get articles", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
 
@@ -298,38 +273,30 @@ func (ctrl *UserController) GetUsers(c *gin.Context) {
 
 // GetUser: 특정 사용자 조회 (Admin용)
 func (ctrl *UserController) GetUser(c *gin.Context) {
+	log := requestLogger(c)
+
 	encodedId := c.Param("id")
 	if encodedId == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "username required"})
+		problem.Abort(c, problem.Invalid("id", "required", "id is required"))
 		return
 	}
 	id, err := url.PathUnescape(encodedId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid username encoding"})
+		problem.Abort(c, problem.Invalid("id", "invalid", "id is not a valid path segment"))
 		return
 	}
-	if len(id) > 256 {
-		log.Printf("[WARN] email too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validEmail, err := param.ValidEmail(id)
+	id, err = userIDParam.Bind(id)
 	if err != nil {
-		log.Printf("[WARN] email error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validEmail {
-		log.Printf("[WARN] email is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		log.Warn("invalid id", "error", err)
+		problem.Abort(c, err)
 		return
 	}
 
 	ctx := c.Request.Context()
 	result, err := ctrl.UserModel.GetUser(ctx, id)
 	if err != nil {
-		log.Printf("[ERROR] failed to get articles: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to get articles", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
 
@@ -337,89 +304,24 @@ func (ctrl *UserController) GetUser(c *gin.Context) {
 }
 
 func (ctrl *UserController) PostUser(c *gin.Context) {
+	log := requestLogger(c)
+
 	var req PostUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[WARN] failed to parse request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	// 아이디 검증
-	id := req.ID
-	if id == "" {
-		log.Printf("[WARN] id is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if len(id) > 256 {
-		log.Printf("[WARN] id too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validId, err := param.ValidEmail(id)
-	if err != nil {
-		log.Printf("[WARN] id error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validId {
-		log.Printf("[WARN] id is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	// 이름 검증
-	name := req.Name
-	if name == "" {
-		log.Printf("[WARN] name is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if len(name) > 64 {
-		log.Printf("[WARN] name too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validName, err := param.ValidNameKR(name)
-	if err != nil {
-		log.Printf("[WARN] name error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validName {
-		log.Printf("[WARN] name is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	// 이메일 검증
-	email := req.Email
-	if email == "" {
-		log.Printf("[WARN] email is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if len(email) > 256 {
-		log.Printf("[WARN] email too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validEmail, err := param.ValidEmail(email)
-	if err != nil {
-		log.Printf("[WARN] email error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validEmail {
-		log.Printf("[WARN] email is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if err := param.Bind(c, &req); err != nil {
+		log.Warn("invalid request", "error", err)
+		problem.Abort(c, err)
 		return
 	}
+	id, name, email := req.ID, req.Name, req.Email
+
 	ctx := c.Request.Context()
 	claims := GetClaims(c)
 	ctrl.AuthModel.PostUser(ctx, id, name, email)
 
 	user, err := ctrl.AuthModel.GetUser(ctx, id)
 	if err != nil {
-		log.Printf("[ERROR] failed to get user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to get user", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
 
@@ -429,8 +331,8 @@ func (ctrl *UserController) PostUser(c *gin.Context) {
 		claims.ID, claims.Name,
 	)
 	if err2 != nil {
-		log.Printf("[ERROR] failed to create user: %v", err2)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to create user", "error", err2)
+		problem.Abort(c, problem.Internal(err2))
 		return
 	}
 
@@ -438,89 +340,44 @@ func (ctrl *UserController) PostUser(c *gin.Context) {
 }
 
 func (ctrl *UserController) PutUser(c *gin.Context) {
-	var req PostUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("[WARN] failed to parse request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	// 아이디 검증
-	id := c.Param("id")
-	if id == "" {
-		log.Printf("[WARN] id is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if len(id) > 256 {
-		log.Printf("[WARN] id too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validId, err := param.ValidEmail(id)
-	if err != nil {
-		log.Printf("[WARN] id error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validId {
-		log.Printf("[WARN] id is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	// 이름 검증
-	name := req.Name
-	if name == "" {
-		log.Printf("[WARN] name is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	log := requestLogger(c)
+
+	var req PutUserRequest
+	if err := param.Bind(c, &req); err != nil {
+		log.Warn("invalid request", "error", err)
+		problem.Abort(c, err)
 		return
 	}
-	if len(name) > 64 {
-		log.Printf("[WARN] name too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	id := req.ID
+
+	ctx := c.Request.Context()
+	claims := GetClaims(c)
+
+	// 요청에 없는 항목은 기존 값을 유지
+	user, err := ctrl.AuthModel.GetUser(ctx, id)
+	if errors.Is(err, ErrUserNotFound) {
+		log.Warn("user not found", "id", id)
+		problem.Abort(c, problem.NotFound("user not found"))
 		return
 	}
-	validName, err := param.ValidNameKR(name)
 	if err != nil {
-		log.Printf("[WARN] name error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		log.Error("failed to get user", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
-	if !validName {
-		log.Printf("[WARN] name is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
+	name, email := user.Name, user.Email
+	if req.Name != nil {
+		name = *req.Name
 	}
-	// 이메일 검증
-	email := req.Email
-	if email == "" {
-		log.Printf("[WARN] email is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
+	if req.Email != nil {
+		email = *req.Email
 	}
-	if len(email) > 256 {
-		log.Printf("[WARN] email too long")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	validEmail, err := param.ValidEmail(email)
-	if err != nil {
-		log.Printf("[WARN] email error")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	if !validEmail {
-		log.Printf("[WARN] email is invalid")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
-		return
-	}
-	ctx := c.Request.Context()
-	claims := GetClaims(c)
 	ctrl.AuthModel.PutUser(ctx, id, name, email)
 
-	user, err := ctrl.AuthModel.GetUser(ctx, id)
+	user, err = ctrl.AuthModel.GetUser(ctx, id)
 	if err != nil {
-		log.Printf("[ERROR] failed to get user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to get user", "error", err)
+		problem.Abort(c, problem.Internal(err))
 		return
 	}
 
@@ -530,8 +387,8 @@ func (ctrl *UserController) PutUser(c *gin.Context) {
 		claims.ID, claims.Name,
 	)
 	if err2 != nil {
-		log.Printf("[ERROR] failed to update user: %v", err2)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "error"})
+		log.Error("failed to update user", "error", err2)
+		problem.Abort(c, problem.Internal(err2))
 		return
 	}
 
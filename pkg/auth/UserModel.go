@@ -5,9 +5,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"strings"
 	"time"
+
+	"parkjunwoo.com/microstral/pkg/logger"
 )
 
 type UserModel struct {
@@ -85,7 +86,7 @@ func (m *UserModel) GetUsers(
 			&user.DeletedAt,
 			&user.Groups,
 		); err != nil {
-			log.Printf("scan error: %v", err)
+			logger.Component(logger.FromContext(ctx), "auth").Warn("scan error", "error", err)
 			continue
 		}
 		users = append(users, user)
@@ -3,13 +3,37 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/lib/pq"
+
+	"parkjunwoo.com/microstral/pkg/flag"
+	"parkjunwoo.com/microstral/pkg/param"
+)
+
+var (
+	// 비밀번호 초기화 요청의 이메일
+	forgotEmailParam = param.Param{Name: "email", Type: param.EMAIL, Required: true, MaxBytes: 200}
+	// 경로 파라미터의 사용자 ID (이메일)
+	userIDParam = param.Param{Name: "id", Type: param.EMAIL, Required: true, MaxBytes: 256}
+
+	// 사용자 목록 조회 쿼리
+	getUsersSchema = param.NewSchema("GetUsers",
+		param.Param{Name: "limit", Default: "60", Type: param.FLAG, Flag: flag.UINT, Min: param.Float(1)},
+		param.Param{Name: "page", Default: "1", Type: param.FLAG, Flag: flag.UINT, Min: param.Float(1)},
+		param.Param{Name: "order", Default: "created_at", Enum: []string{"created_at", "name"}},
+		param.Param{Name: "desc", Default: "DESC", Enum: []string{"ASC", "DESC"}, EnumFold: true},
+		param.Param{Name: "search", Type: param.TITLE_KR},
+		param.Param{Name: "group", Type: param.ID},
+	)
 )
 
+// ErrUserNotFound는 인증 공급자에 사용자가 없을 때 GetUser가 감싸서 반환하는 오류입니다.
+var ErrUserNotFound = errors.New("user not found")
+
 type AuthProviderModel interface {
 	// JWT 검증 미들웨어
 	Authenticator() gin.HandlerFunc
@@ -19,7 +43,7 @@ type AuthProviderModel interface {
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenResponse, error)
 	// 전체 사용자 목록 조회
 	GetUsers(ctx context.Context) (*AllUsers, error)
-	// 사용자 조회
+	// 사용자 조회 (없으면 ErrUserNotFound)
 	GetUser(ctx context.Context, id string) (*UsersItem, error)
 	// 특정 사용자 그룹 목록 조회
 	GetGroups(ctx context.Context, id string) ([]string, error)
@@ -72,15 +96,17 @@ type ForgotRequest struct {
 
 // PostUserRequest: 사용자 생성 요청 데이터
 type PostUserRequest struct {
-	ID    string `json:"id" binding:"required"`
-	Name  string `json:"name" binding:"required"`
-	Email string `json:"email" binding:"required,email"`
+	ID    string `json:"id" param:"type=EMAIL,required,maxbytes=256"`
+	Name  string `json:"name" param:"type=NAME_KR,required,max=64"`
+	Email string `json:"email" param:"type=EMAIL,required,maxbytes=256"`
 }
 
-// PutUserRequest: 사용자 정보 업데이트 요청 데이터
+// PutUserRequest: 사용자 정보 업데이트 요청 데이터 (ID는 경로 파라미터)
+// Name, Email은 생략하면 기존 값을 유지합니다.
 type PutUserRequest struct {
-	Name  *string `json:"name,omitempty"`
-	Email *string `json:"email,omitempty"`
+	ID    string  `uri:"id" json:"-" param:"type=EMAIL,required,maxbytes=256"`
+	Name  *string `json:"name,omitempty" param:"type=NAME_KR,max=64"`
+	Email *string `json:"email,omitempty" param:"type=EMAIL,maxbytes=256"`
 }
 
 type TokenResponse struct {
@@ -0,0 +1,149 @@
+// parkjunwoo.com/microstral/pkg/cert/acme.go
+package cert
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/file"
+)
+
+// NewACMEManager는 환경 변수 설정으로 ACME 인증서 관리자를 생성합니다.
+// 인증서는 최초 TLS 연결 시 발급받고 만료 전에 자동으로 갱신합니다.
+// - ACME_HOSTS: 인증서를 발급받을 호스트 이름 목록, 쉼표로 구분 (기본값 defaultHost)
+// - ACME_EMAIL: ACME 계정 연락처 이메일
+// - ACME_DIRECTORY_URL: ACME 디렉터리 URL (기본값 Let's Encrypt 운영 서버)
+// - ACME_CA_BUNDLE: ACME 서버 인증서를 검증할 CA 번들 경로 (pebble 같은 테스트 서버용)
+// - ACME_RENEW_BEFORE: 만료 몇 시간 전에 갱신할지 (기본값 720h, 30일)
+func NewACMEManager(cache autocert.Cache, defaultHost string) (*autocert.Manager, error) {
+	var hosts []string
+	for _, host := range strings.Split(env.GetEnv("ACME_HOSTS", defaultHost), ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("ACME_HOSTS is required")
+	}
+
+	client := &acme.Client{DirectoryURL: env.GetEnv("ACME_DIRECTORY_URL", autocert.DefaultACMEDirectory)}
+	if caFile := env.GetEnv("ACME_CA_BUNDLE", ""); caFile != "" {
+		pool, err := LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		client.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	return &autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       cache,
+		HostPolicy:  autocert.HostWhitelist(hosts...),
+		RenewBefore: env.GetEnvDuration("ACME_RENEW_BEFORE", 30*24*time.Hour),
+		Client:      client,
+		Email:       env.GetEnv("ACME_EMAIL", ""),
+	}, nil
+}
+
+// DirCache는 인증서를 디렉터리에 저장하는 autocert.Cache입니다.
+// file 패키지의 파일 락을 사용하므로 같은 볼륨을 공유하는 복제본끼리 쓰기가 섞이지 않습니다.
+//
+// 락은 파일 하나를 읽고 쓰는 동안만 잡히며 인증서 발급 과정 전체를 감싸지 않습니다.
+// 캐시가 비어 있을 때 여러 복제본이 동시에 TLS 연결을 받으면 복제본마다 인증서를 따로 발급받고
+// 마지막에 쓴 인증서가 남습니다. CA의 중복 발급 한도(Let's Encrypt는 같은 호스트 목록에 주당 5개)를
+// 넘지 않도록 복제본 하나로 먼저 발급받아 캐시를 채운 뒤 복제본을 늘리십시오.
+type DirCache string
+
+// Get은 key에 해당하는 캐시 파일을 읽습니다.
+func (d DirCache) Get(ctx context.Context, key string) ([]byte, error) {
+	path := d.path(key)
+	if !file.FileExists(path) {
+		return nil, autocert.ErrCacheMiss
+	}
+	data, err := file.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// 다른 복제본이 락만 만들고 아직 쓰지 않은 빈 파일은 없는 것으로 처리
+	if len(data) == 0 {
+		return nil, autocert.ErrCacheMiss
+	}
+	return data, nil
+}
+
+// Put은 key에 해당하는 캐시 파일을 씁니다.
+func (d DirCache) Put(ctx context.Context, key string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+	return file.WriteFile(d.path(key), data, 0600)
+}
+
+// Delete는 key에 해당하는 캐시 파일을 삭제합니다.
+func (d DirCache) Delete(ctx context.Context, key string) error {
+	path := d.path(key)
+	lock, err := file.LockFile(path, file.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (d DirCache) path(key string) string {
+	return filepath.Join(string(d), filepath.Base(filepath.Clean("/"+key)))
+}
+
+// RedisCache는 인증서를 Redis에 저장하는 autocert.Cache입니다.
+// 볼륨을 공유하지 않는 복제본끼리 인증서를 공유할 때 사용합니다.
+// DirCache와 마찬가지로 발급 과정은 잠그지 않으므로 캐시가 빈 상태에서 복제본이 동시에 발급받을 수 있습니다.
+type RedisCache struct {
+	Client *redis.Client
+	Prefix string
+}
+
+// Get은 key에 해당하는 캐시 값을 읽습니다.
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.Client.Get(ctx, r.Prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, autocert.ErrCacheMiss
+	}
+	return data, err
+}
+
+// Put은 key에 해당하는 캐시 값을 저장합니다.
+func (r *RedisCache) Put(ctx context.Context, key string, data []byte) error {
+	return r.Client.Set(ctx, r.Prefix+key, data, 0).Err()
+}
+
+// Delete는 key에 해당하는 캐시 값을 삭제합니다.
+func (r *RedisCache) Delete(ctx context.Context, key string) error {
+	return r.Client.Del(ctx, r.Prefix+key).Err()
+}
+
+// ACMEConfigFromEnv는 ACME 관리자로 인증서를 발급받는 서버용 tls.Config를 생성합니다.
+// TLS-ALPN-01 챌린지를 위해 acme-tls/1 프로토콜을 추가하며, 나머지 설정은 ConfigFromEnv와 같습니다.
+func ACMEConfigFromEnv(m *autocert.Manager) (*tls.Config, error) {
+	cfg, err := ConfigFromEnv(m.GetCertificate)
+	if err != nil {
+		return nil, err
+	}
+	cfg.NextProtos = []string{"h2", "http/1.1", acme.ALPNProto}
+	return cfg, nil
+}
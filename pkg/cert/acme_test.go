@@ -0,0 +1,139 @@
+// parkjunwoo.com/microstral/pkg/cert/acme_test.go
+package cert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+func TestDirCache(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		key     string
+		setup   func(t *testing.T, dir string)
+		want    []byte
+		wantErr error
+	}{
+		{"miss", "example.com", nil, nil, autocert.ErrCacheMiss},
+		{"put then get", "example.com", func(t *testing.T, dir string) {
+			if err := DirCache(dir).Put(ctx, "example.com", []byte("cert")); err != nil {
+				t.Fatal(err)
+			}
+		}, []byte("cert"), nil},
+		{"empty file is a miss", "example.com", func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "example.com"), nil, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}, nil, autocert.ErrCacheMiss},
+		{"deleted", "example.com", func(t *testing.T, dir string) {
+			c := DirCache(dir)
+			if err := c.Put(ctx, "example.com", []byte("cert")); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Delete(ctx, "example.com"); err != nil {
+				t.Fatal(err)
+			}
+		}, nil, autocert.ErrCacheMiss},
+		{"key stays inside the directory", "../../outside", func(t *testing.T, dir string) {
+			if err := DirCache(dir).Put(ctx, "../../outside", []byte("cert")); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "outside")); err != nil {
+				t.Fatalf("cache file not in directory: %v", err)
+			}
+		}, []byte("cert"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "acme")
+			if tt.setup != nil {
+				if err := os.MkdirAll(dir, 0700); err != nil {
+					t.Fatal(err)
+				}
+				tt.setup(t, dir)
+			}
+			got, err := DirCache(dir).Get(ctx, tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != string(tt.want) {
+				t.Errorf("Get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestACMEPebble은 pebble 테스트 ACME 서버에서 인증서를 발급받고, 같은 DirCache를 쓰는 다른 관리자가
+// 다시 발급받지 않고 캐시된 인증서를 쓰는지 확인합니다.
+// pebble을 PEBBLE_VA_ALWAYS_VALID=1로 실행하고 다음 환경 변수를 설정해야 실행됩니다.
+// - PEBBLE_DIRECTORY_URL: pebble 디렉터리 URL (예: https://localhost:14000/dir)
+// - PEBBLE_CA_BUNDLE: pebble API 서버 인증서의 CA 파일 (예: pebble.minica.pem)
+func TestACMEPebble(t *testing.T) {
+	directory := os.Getenv("PEBBLE_DIRECTORY_URL")
+	if directory == "" {
+		t.Skip("PEBBLE_DIRECTORY_URL is not set")
+	}
+	const host = "mist.test"
+	t.Setenv("ACME_HOSTS", host)
+	t.Setenv("ACME_DIRECTORY_URL", directory)
+	t.Setenv("ACME_CA_BUNDLE", os.Getenv("PEBBLE_CA_BUNDLE"))
+
+	cache := DirCache(t.TempDir())
+	issue := func() *x509.Certificate {
+		m, err := NewACMEManager(cache, host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Client.HTTPClient.Transport = pebbleFinalizeTransport{m.Client.HTTPClient.Transport}
+		c, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: host})
+		if err != nil {
+			t.Fatalf("GetCertificate: %v", err)
+		}
+		leaf, err := x509.ParseCertificate(c.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return leaf
+	}
+
+	first := issue()
+	if !slices.Contains(first.DNSNames, host) {
+		t.Fatalf("certificate DNS names = %v, want %s", first.DNSNames, host)
+	}
+	if _, err := cache.Get(context.Background(), host+"+rsa"); err != nil {
+		t.Fatalf("certificate not cached: %v", err)
+	}
+
+	second := issue()
+	if first.SerialNumber.Cmp(second.SerialNumber) != 0 {
+		t.Errorf("second manager issued serial %s, want cached %s", second.SerialNumber, first.SerialNumber)
+	}
+}
+
+// pebbleFinalizeTransport는 pebble의 주문 완료(finalize) 응답에 주문 URL을 Location 헤더로 추가합니다.
+// pebble은 완료 요청에 항상 processing 상태를 Location 없이 돌려주는데,
+// acme.Client는 이 헤더의 주문 URL을 조회하며 발급을 기다리기 때문입니다.
+type pebbleFinalizeTransport struct {
+	http.RoundTripper
+}
+
+func (t pebbleFinalizeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.RoundTripper.RoundTrip(req)
+	if err == nil && strings.Contains(req.URL.Path, "/finalize-order/") && res.Header.Get("Location") == "" {
+		order := *req.URL
+		order.Path = strings.Replace(order.Path, "/finalize-order/", "/my-order/", 1)
+		res.Header.Set("Location", order.String())
+	}
+	return res, err
+}
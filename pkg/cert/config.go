@@ -0,0 +1,129 @@
+// parkjunwoo.com/microstral/pkg/cert/config.go
+package cert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/file"
+)
+
+// ConfigFromEnv는 환경 변수 설정으로 서버용 tls.Config를 생성합니다.
+// 인증서는 getCertificate(Reloader.GetCertificate 등)에서 가져오며, 나머지 설정은 아래 환경 변수를 따릅니다.
+// - TLS_MIN_VERSION: 1.0, 1.1, 1.2, 1.3 (기본값 1.2)
+// - TLS_CIPHER_SUITES: 쉼표로 구분한 암호 스위트 이름 (TLS 1.2 이하에만 적용, 기본값 Go 기본 목록)
+// - TLS_CLIENT_CA: 클라이언트 인증서를 검증할 CA 번들 경로 (mTLS)
+// - TLS_CLIENT_AUTH: none, request, require, verify, require_and_verify
+// (기본값: TLS_CLIENT_CA가 있으면 require_and_verify, 없으면 none)
+func ConfigFromEnv(getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) (*tls.Config, error) {
+	minVersion, err := ParseVersion(env.GetEnv("TLS_MIN_VERSION", "1.2"))
+	if err != nil {
+		return nil, err
+	}
+	cipherSuites, err := ParseCipherSuites(env.GetEnv("TLS_CIPHER_SUITES", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+		GetCertificate: getCertificate,
+	}
+
+	caFile := env.GetEnv("TLS_CLIENT_CA", "")
+	defaultAuth := "none"
+	if caFile != "" {
+		defaultAuth = "require_and_verify"
+	}
+	clientAuth, err := ParseClientAuth(env.GetEnv("TLS_CLIENT_AUTH", defaultAuth))
+	if err != nil {
+		return nil, err
+	}
+	cfg.ClientAuth = clientAuth
+
+	if caFile != "" {
+		pool, err := LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+	} else if clientAuth >= tls.VerifyClientCertIfGiven {
+		return nil, fmt.Errorf("TLS_CLIENT_AUTH=%s requires TLS_CLIENT_CA", env.GetEnv("TLS_CLIENT_AUTH", ""))
+	}
+	return cfg, nil
+}
+
+// LoadCertPool은 PEM 형식의 CA 번들 파일을 읽어 인증서 풀을 생성합니다.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := file.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// ParseVersion은 "1.2" 형식의 TLS 버전 문자열을 변환합니다.
+func ParseVersion(value string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "tls") {
+	case "1.0", "10":
+		return tls.VersionTLS10, nil
+	case "1.1", "11":
+		return tls.VersionTLS11, nil
+	case "1.2", "12":
+		return tls.VersionTLS12, nil
+	case "1.3", "13":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version: %s", value)
+}
+
+// ParseCipherSuites는 쉼표로 구분한 암호 스위트 이름 목록을 ID 목록으로 변환합니다.
+// 빈 문자열이면 nil(Go 기본 목록)을 반환합니다. 안전하지 않은 스위트는 허용하지 않습니다.
+func ParseCipherSuites(value string) ([]uint16, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	suites := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		suites[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := suites[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher suite: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ParseClientAuth는 클라이언트 인증서 요구 수준 문자열을 변환합니다.
+func ParseClientAuth(value string) (tls.ClientAuthType, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+	return tls.NoClientCert, fmt.Errorf("unsupported TLS client auth: %s", value)
+}
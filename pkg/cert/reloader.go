@@ -0,0 +1,77 @@
+// parkjunwoo.com/microstral/pkg/cert/reloader.go
+package cert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"parkjunwoo.com/microstral/pkg/file"
+	"parkjunwoo.com/microstral/pkg/logger"
+)
+
+// Reloader는 인증서/개인키 파일이 바뀌면 다시 읽어 tls.Config.GetCertificate로 제공합니다.
+// 인증서를 갱신해도 서버를 재시작할 필요가 없습니다.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Pointer[tls.Certificate]
+	mu       sync.Mutex
+	stop     context.CancelFunc
+}
+
+// NewReloader는 인증서를 읽고 ctx가 끝나거나 Close를 호출할 때까지 두 파일의 변경을 감시합니다.
+// 최초 로드나 감시 시작에 실패하면 이미 시작한 감시를 멈추고 오류를 반환합니다.
+func NewReloader(ctx context.Context, certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	ctx, r.stop = context.WithCancel(ctx)
+	for _, path := range []string{certFile, keyFile} {
+		// 최초 호출은 위에서 이미 로드했으므로 WatchFile의 첫 콜백은 건너뜀
+		first := true
+		err := file.WatchFile(ctx, path, func([]byte) {
+			if first {
+				first = false
+				return
+			}
+			if err := r.Reload(); err != nil {
+				// 인증서와 개인키 중 하나만 바뀐 시점일 수 있으므로 기존 인증서를 유지
+				logger.Component(slog.Default(), "cert").Warn("failed to reload certificate, keeping previous one", "cert", certFile, "error", err)
+			}
+		})
+		if err != nil {
+			r.stop()
+			return nil, fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Close는 파일 감시를 멈춥니다. 마지막으로 읽은 인증서는 계속 제공합니다.
+func (r *Reloader) Close() error {
+	r.stop()
+	return nil
+}
+
+// Reload는 인증서 파일을 다시 읽습니다. 실패하면 기존 인증서를 유지합니다.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert.Store(&cert)
+	logger.Component(slog.Default(), "cert").Info("certificate loaded", "cert", r.certFile, "not_after", cert.Leaf.NotAfter)
+	return nil
+}
+
+// GetCertificate는 현재 인증서를 반환합니다. (tls.Config.GetCertificate)
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
@@ -0,0 +1,96 @@
+// parkjunwoo.com/microstral/pkg/cert/reloader_test.go
+package cert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "fullchain.pem"), filepath.Join(dir, "privkey.pem")
+	writeTestCert(t, certFile, keyFile, 1)
+
+	r, err := NewReloader(context.Background(), certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	serial := func() int64 {
+		c, _ := r.GetCertificate(nil)
+		return c.Leaf.SerialNumber.Int64()
+	}
+	if got := serial(); got != 1 {
+		t.Fatalf("serial = %d, want 1", got)
+	}
+
+	writeTestCert(t, certFile, keyFile, 2)
+	deadline := time.Now().Add(3 * time.Second)
+	for serial() != 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := serial(); got != 2 {
+		t.Fatalf("serial after rewrite = %d, want 2", got)
+	}
+
+	r.Close()
+	time.Sleep(100 * time.Millisecond)
+	writeTestCert(t, certFile, keyFile, 3)
+	time.Sleep(time.Second)
+	if got := serial(); got != 2 {
+		t.Errorf("serial after Close = %d, want 2", got)
+	}
+}
+
+func TestNewReloaderMissingKey(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "fullchain.pem"), filepath.Join(dir, "privkey.pem")
+	writeTestCert(t, certFile, keyFile, 1)
+	if err := os.Remove(keyFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReloader(context.Background(), certFile, keyFile); err == nil {
+		t.Fatal("NewReloader succeeded without a private key")
+	}
+}
+
+// writeTestCert는 serial 번호를 가진 자체 서명 인증서와 개인키를 씁니다.
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "mist.test"},
+		DNSNames:     []string{"mist.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 개인키를 먼저 써서 인증서가 바뀐 시점에는 짝이 맞는 키가 있도록 함
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
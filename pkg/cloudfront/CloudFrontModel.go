@@ -11,13 +11,15 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/logger"
 )
 
 type CloudFrontModel struct {
@@ -42,8 +44,8 @@ func NewCloudFrontModel(awsCfg aws.Config) *CloudFrontModel {
 		SecretId: &cloudfrontSecretName,
 	})
 	if err != nil {
-		log.Fatalf("unable to retrieve secret %s", cloudfrontSecretName)
-		return nil
+		logger.Component(slog.Default(), "cloudfront").Error("unable to retrieve secret", "secret", cloudfrontSecretName, "error", err)
+		os.Exit(1)
 	}
 	// CognitoModel 인스턴스 생성
 	return &CloudFrontModel{
@@ -4,6 +4,7 @@ package env
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // GetEnv는 환경 변수 값을 string으로 반환합니다.
@@ -60,3 +61,18 @@ func GetEnvFloat64(key string, def float64) float64 {
 	}
 	return def
 }
+
+// GetEnvDuration는 환경 변수 값을 time.Duration으로 변환하여 반환합니다.
+// "500ms", "2s" 같은 Duration 문자열과 초 단위 정수("30")를 모두 허용합니다.
+func GetEnvDuration(key string, def time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, err := time.ParseDuration(val); err == nil {
+			return d
+		}
+		if sec, err := strconv.Atoi(val); err == nil {
+			return time.Duration(sec) * time.Second
+		}
+		return def
+	}
+	return def
+}
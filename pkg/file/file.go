@@ -2,14 +2,18 @@
 package file
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/gofrs/flock"
 
 	"github.com/fsnotify/fsnotify"
+
+	"parkjunwoo.com/microstral/pkg/logger"
 )
 
 const (
@@ -85,8 +89,12 @@ func ReadFile(path string) ([]byte, error) {
 	if !FileExists(path) {
 		return nil, fmt.Errorf("file not found: %s", path)
 	}
-	LockFile(path, LOCK_SH)
-	defer LockFile(path, LOCK_UN)
+	lock, err := LockFile(path, LOCK_SH)
+	if err != nil {
+		return nil, err
+	}
+	// 새 Flock으로 LOCK_UN을 호출하면 획득한 락이 풀리지 않으므로 획득한 락으로 해제
+	defer lock.Unlock()
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -96,14 +104,20 @@ func ReadFile(path string) ([]byte, error) {
 }
 
 func WriteFile(path string, content []byte, flag os.FileMode) error {
-	LockFile(path, LOCK_EX)
-	defer LockFile(path, LOCK_UN)
+	lock, err := LockFile(path, LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
 	return os.WriteFile(path, content, flag)
 }
 
 func AppendFile(path string, content []byte, flag os.FileMode) error {
-	LockFile(path, LOCK_EX)
-	defer LockFile(path, LOCK_UN)
+	lock, err := LockFile(path, LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, flag)
 	if err != nil {
 		return err
@@ -113,7 +127,12 @@ func AppendFile(path string, content []byte, flag os.FileMode) error {
 	return err
 }
 
-func WatchFile(path string, onChange func([]byte)) error {
+// WatchFile은 path 파일을 읽어 onChange를 호출하고, 이후 파일이 바뀔 때마다 다시 호출합니다.
+// 파일 대신 상위 디렉터리를 감시하므로 심볼릭 링크 교체나 rename 방식의 원자적 갱신
+// (certbot 갱신, 일부 에디터)도 감지합니다. 디렉터리에 이벤트가 생길 때마다 심볼릭 링크를 따라간
+// 실제 경로를 비교하므로, Kubernetes ConfigMap/Secret처럼 path는 그대로 두고 중간 링크(..data)만
+// 바꾸는 갱신도 감지합니다. ctx가 끝나면 감시를 멈추고 감시자를 닫습니다.
+func WatchFile(ctx context.Context, path string, onChange func([]byte)) error {
 	// 최초 1회 실행
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -125,25 +144,49 @@ func WatchFile(path string, onChange func([]byte)) error {
 	if err != nil {
 		return err
 	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
 
+	target := filepath.Clean(path)
+	resolved, _ := filepath.EvalSymlinks(target)
 	go func() {
 		defer watcher.Close()
-		lastRead := time.Now()
+		// 쓰기 이벤트가 여러 번 발생하므로 마지막 이벤트 후 일정 시간이 지나면 한 번만 읽음 (디바운스)
+		var debounce <-chan time.Time
 		for {
 			select {
-			case event := <-watcher.Events:
-				// 일부 에디터는 쓰기 이벤트 여러 번 발생 → 최소 시간 간격 체크 (디바운스)
-				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 && time.Since(lastRead) > 300*time.Millisecond {
-					data, err := os.ReadFile(path)
-					if err == nil {
-						onChange(data)
-						lastRead = time.Now()
-					}
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) == target && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					debounce = time.After(300 * time.Millisecond)
+					continue
+				}
+				// 링크 대상이 바뀌었는지 확인 (..data 교체 등)
+				if current, err := filepath.EvalSymlinks(target); err == nil && current != resolved {
+					resolved = current
+					debounce = time.After(300 * time.Millisecond)
+				}
+			case <-debounce:
+				debounce = nil
+				data, err := os.ReadFile(path)
+				if err != nil {
+					logger.Component(slog.Default(), "file").Warn("failed to read watched file", "path", path, "error", err)
+					continue
+				}
+				onChange(data)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
 				}
-			case err := <-watcher.Errors:
-				log.Printf("watcher error: %v", err)
+				logger.Component(slog.Default(), "file").Warn("watcher error", "path", path, "error", err)
 			}
 		}
 	}()
-	return watcher.Add(path)
+	return nil
 }
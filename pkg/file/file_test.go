@@ -0,0 +1,65 @@
+// parkjunwoo.com/microstral/pkg/file/file_test.go
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFileStopsWithContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("v1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan string, 10)
+	if err := WatchFile(ctx, path, func(data []byte) { changes <- string(data) }); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Fatalf("onChange(%q), want %q", got, want)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("onChange not called, want %q", want)
+		}
+	}
+	expect("v1")
+
+	if err := os.WriteFile(path, []byte("v2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	expect("v2")
+
+	cancel()
+	// 감시 고루틴이 종료될 시간을 준 뒤 파일을 바꿈
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("v3"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-changes:
+		t.Fatalf("onChange(%q) called after context was canceled", got)
+	case <-time.After(time.Second):
+	}
+}
+
+func TestWatchFileMissing(t *testing.T) {
+	called := false
+	err := WatchFile(context.Background(), filepath.Join(t.TempDir(), "missing.txt"), func([]byte) { called = true })
+	if !os.IsNotExist(err) {
+		t.Fatalf("WatchFile error = %v, want not exist", err)
+	}
+	if called {
+		t.Error("onChange called for missing file")
+	}
+}
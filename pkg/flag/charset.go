@@ -0,0 +1,311 @@
+// parkjunwoo.com/microstral/pkg/flag/charset.go
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidChar는 허용되지 않은 문자가 포함된 경우의 오류입니다.
+var ErrInvalidChar = errors.New("invalid character")
+
+// ErrInvalidNumber는 숫자 플래그(UINT, INT, UNUM, NUM, OCT, HEX, BOOL)에 맞지 않는 값의 오류입니다.
+var ErrInvalidNumber = errors.New("invalid number")
+
+// 글자 하나(기본 문자)에 붙을 수 있는 결합 문자 최대 개수
+// 국기 태그 시퀀스(🏴 + 태그 문자 + 종료 태그)를 허용하면서 Zalgo 텍스트처럼 결합 문자를 쌓는 입력을 막습니다.
+const maxCombining = 8
+
+// 발음 구별 기호 등 결합 문자(Mn)를 붙여 쓰는 문자 집합
+// 영문(ALPHA)은 ASCII만 뜻하므로 제외합니다. (결합 문자를 허용하면 "admin" + U+0301 같은 값이 통과함)
+const markScripts = LATIN | LATIN_EXT | GREEK | CYRILLIC | HEBREW | ARABIC | DEVANAGARI | KATAKANA | HIRAGANA
+
+// 숫자 해석에 쓰이는 플래그 (문자 집합 플래그 없이 단독으로 쓰면 값 전체를 숫자로 검사)
+const numeric = BOOL | UINT | UNUM | OCT | HEX
+
+// CharError는 허용되지 않은 문자와 그 위치(문자 단위, 0부터)를 담은 오류입니다.
+type CharError struct {
+	Rune     rune
+	Position int
+}
+
+func (e *CharError) Error() string {
+	return fmt.Sprintf("%s %q (U+%04X) at position %d", ErrInvalidChar, e.Rune, e.Rune, e.Position)
+}
+
+func (e *CharError) Unwrap() error {
+	return ErrInvalidChar
+}
+
+// charClass는 플래그 하나가 허용하는 문자 범위입니다.
+type charClass struct {
+	flag  uint64
+	table *unicode.RangeTable
+}
+
+var (
+	digitTable = rangeTable(r16('0', '9'))
+	octTable   = rangeTable(r16('0', '7'))
+	hexTable   = rangeTable(r16('0', '9'), r16('A', 'F'), r16('a', 'f'))
+	upperTable = rangeTable(r16('A', 'Z'))
+	lowerTable = rangeTable(r16('a', 'z'))
+
+	// 키보드 자판의 특수문자와 공백
+	specialTable = rangeTable(r16(' ', '/'), r16(':', '@'), r16('[', '`'), r16('{', '~'))
+
+	// ISO-8859-1 문자 (ASCII 영문자 + À-ÿ, ×와 ÷ 제외)
+	latinTable = rangeTable(r16('A', 'Z'), r16('a', 'z'), r16(0x00C0, 0x00D6), r16(0x00D8, 0x00F6), r16(0x00F8, 0x00FF))
+
+	// 라틴 확장 A/B, 확장 추가, 확장 C/D
+	latinExtTable = rangeTable(r16(0x0100, 0x024F), r16(0x1E00, 0x1EFF), r16(0x2C60, 0x2C7F), r16(0xA720, 0xA7FF))
+
+	// 간체자(GB2312)는 모두 CJK 통합 한자 기본 블록에 있음
+	// 유니코드는 간체/번체를 코드 범위로 구분하지 않으므로 기본 블록 전체를 허용
+	hanziSimpleTable = rangeTable(r16(0x4E00, 0x9FFF))
+
+	// 장음 부호(ー)와 반각 장음 부호는 Common 스크립트라 별도로 추가
+	katakanaExtTable = rangeTable(r16(0x30FC, 0x30FC), r16(0xFF70, 0xFF70))
+	// 탁점/반탁점(゙゚゛゜)과 장음 부호
+	hiraganaExtTable = rangeTable(r16(0x3099, 0x309C), r16(0x30FC, 0x30FC))
+
+	// 이모지 (기호/픽토그램, 딩뱃, 국기용 지역 표시자, 피부색 수정자 등)
+	emojiTable = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+			{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+			{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+			{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+			{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+			{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+			{Lo: 0x2194, Hi: 0x21AA, Stride: 1},
+			{Lo: 0x231A, Hi: 0x23FF, Stride: 1},
+			{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+			{Lo: 0x25AA, Hi: 0x25FE, Stride: 1},
+			{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+			{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+			{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+			{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+			{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+			{Lo: 0x3297, Hi: 0x3299, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1F000, Hi: 0x1FAFF, Stride: 1},
+		},
+		LatinOffset: 2,
+	}
+)
+
+// 플래그별 문자 범위. 숫자 플래그(UINT, UNUM, OCT, HEX)는 문자 집합과 함께 쓰면 해당 숫자 문자를 허용합니다.
+var classes = []charClass{
+	{UINT | UNUM, digitTable},
+	{OCT, octTable},
+	{HEX, hexTable},
+	{SPCIAL, specialTable},
+	{SPCIAL_EXT, specialTable},
+	{EMOJI, emojiTable},
+	{UPPER, upperTable},
+	{LOWER, lowerTable},
+	{LATIN, latinTable},
+	{LATIN_EXT, latinExtTable},
+	{HEBREW, unicode.Hebrew},
+	{CYRILLIC, unicode.Cyrillic},
+	{GREEK, unicode.Greek},
+	{ARABIC, unicode.Arabic},
+	{HANZI, unicode.Han},
+	{HANZI_SIMPLE, hanziSimpleTable},
+	{HANGUL, unicode.Hangul},
+	{KATAKANA, unicode.Katakana},
+	{KATAKANA, katakanaExtTable},
+	{HIRAGANA, unicode.Hiragana},
+	{HIRAGANA, hiraganaExtTable},
+	{DEVANAGARI, unicode.Devanagari},
+}
+
+// Validate는 value가 플래그 f에 맞는지 검사합니다.
+//   - f가 0이면 제한 없이 통과합니다.
+//   - 숫자 플래그(BOOL, UINT, INT, UNUM, NUM, OCT, HEX)만 있으면 값 전체를 해당 형식의 숫자(또는 불리언)로 검사합니다.
+//   - 문자 집합 플래그가 있으면 모든 문자가 활성화된 집합 중 하나에 속해야 합니다.
+//     예: ALPHA_HANGUL_NUM_SPECIAL은 영문 대소문자, 한글, 숫자, 키보드 특수문자(공백 포함)를 허용합니다.
+//   - 결합 문자는 허용된 기본 문자 뒤에 maxCombining개까지 허용합니다.
+//     발음 구별 기호는 기본 문자가 markScripts 집합일 때만, 이모지 ZWJ/이체자 선택자/태그 문자는 EMOJI가 있을 때만 허용합니다.
+//
+// 허용되지 않은 문자가 있으면 그 문자와 위치를 담은 *CharError를 반환합니다.
+func Validate(value string, f uint64) error {
+	if f == 0 {
+		return nil
+	}
+	if f&^numeric == 0 {
+		return validateNumber(value, f)
+	}
+
+	position := 0
+	var base uint64 // 현재 기본 문자가 속한 문자 집합 플래그
+	marks := 0      // 현재 기본 문자에 붙은 결합 문자 수
+	for i, r := range value {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(value[i:]); size <= 1 {
+				return &CharError{Rune: r, Position: position}
+			}
+		}
+		matched := matchFlags(f, r)
+		combining := isCombining(r)
+		if combining {
+			marks++
+		} else {
+			base, marks = matched, 0
+		}
+		allowed := matched != 0
+		// 결합 문자는 허용된 기본 문자 뒤에서, 그 문자 집합이 결합 문자를 쓰는 경우에만 허용
+		if !allowed && combining && base != 0 {
+			allowed = allowsCombining(f, base, r)
+		}
+		if !allowed || marks > maxCombining {
+			return &CharError{Rune: r, Position: position}
+		}
+		position++
+	}
+	return nil
+}
+
+// Allows는 문자 r이 플래그 f에 활성화된 문자 집합 중 하나에 속하는지 반환합니다.
+func Allows(f uint64, r rune) bool {
+	return matchFlags(f, r) != 0
+}
+
+// matchFlags는 f에 활성화된 문자 집합 중 r이 속하는 집합의 플래그를 모두 반환합니다.
+func matchFlags(f uint64, r rune) uint64 {
+	var matched uint64
+	for _, c := range classes {
+		if f&c.flag != 0 && unicode.Is(c.table, r) {
+			matched |= f & c.flag
+		}
+	}
+	// 확장 특수문자: 유니코드 문장 부호/기호/공백 (이모지는 EMOJI로만 허용)
+	if f&SPCIAL_EXT != 0 && (unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.Is(unicode.Zs, r)) && !unicode.Is(emojiTable, r) {
+		matched |= SPCIAL_EXT
+	}
+	return matched
+}
+
+// allowsCombining은 base 집합의 문자 뒤에 결합 문자 r을 붙일 수 있는지 반환합니다.
+func allowsCombining(f uint64, base uint64, r rune) bool {
+	if isEmojiCombining(r) {
+		return f&EMOJI != 0
+	}
+	return base&markScripts != 0
+}
+
+// isCombining은 앞 문자에 붙어 하나의 글자를 이루는 결합 문자인지 반환합니다.
+func isCombining(r rune) bool {
+	return isEmojiCombining(r) || unicode.Is(unicode.Inherited, r) || unicode.Is(unicode.Mn, r)
+}
+
+// isEmojiCombining은 이모지 시퀀스를 이루는 보이지 않는 문자인지 반환합니다.
+// ZWJ(U+200D), 이체자 선택자(U+FE0E, U+FE0F), 키캡(U+20E3), 지역 국기용 태그 문자(U+E0020-E007F)
+func isEmojiCombining(r rune) bool {
+	return r == 0x200D || r == 0xFE0E || r == 0xFE0F || r == 0x20E3 || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+// IsNumeric은 f가 숫자 플래그만으로 이루어져 값 전체를 숫자로 해석하는지 반환합니다.
+func IsNumeric(f uint64) bool {
+	return f != 0 && f&^numeric == 0 && f != BOOL
+}
+
+// validateNumber는 숫자 플래그만 있는 경우 값 전체를 숫자로 해석합니다.
+func validateNumber(value string, f uint64) error {
+	if f == BOOL {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+		}
+		return nil
+	}
+	_, err := ParseNumber(value, f)
+	return err
+}
+
+// ParseNumber는 숫자 플래그 f에 맞게 value를 해석한 값을 반환합니다.
+//   - UINT: 0 이상의 10진 정수, INT: 부호 있는 10진 정수
+//   - UNUM: 0 이상의 실수, NUM: 부호 있는 실수
+//   - OCT: 8진 정수 (0o 또는 0 접두사 허용), HEX: 16진 정수 (0x 접두사 허용)
+//
+// 여러 플래그가 있으면 정수, 실수, 8진, 16진 순서로 먼저 해석되는 값을 사용합니다.
+func ParseNumber(value string, f uint64) (float64, error) {
+	signed := f&BOOL != 0
+	if f&UINT != 0 && isInteger(value, signed) {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return float64(n), nil
+		}
+		if u, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return float64(u), nil
+		}
+	}
+	if f&UNUM != 0 && isFloat(value, signed) {
+		n, _ := strconv.ParseFloat(value, 64)
+		return n, nil
+	}
+	if f&OCT != 0 {
+		digits := strings.TrimPrefix(strings.TrimPrefix(value, "0o"), "0O")
+		if n, err := strconv.ParseUint(digits, 8, 64); err == nil && digits != "" {
+			return float64(n), nil
+		}
+	}
+	if f&HEX != 0 {
+		digits := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		if n, err := strconv.ParseUint(digits, 16, 64); err == nil && digits != "" {
+			return float64(n), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidNumber, value)
+}
+
+func isInteger(value string, signed bool) bool {
+	digits := value
+	if signed && len(value) > 0 && (value[0] == '-' || value[0] == '+') {
+		digits = value[1:]
+	}
+	if digits == "" {
+		return false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isFloat(value string, signed bool) bool {
+	// 16진 실수, 밑줄 구분자 등 Go 문법 전용 표기는 거부
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9', c == '.', c == 'e', c == 'E', c == '-', c == '+':
+		default:
+			return false
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return false
+	}
+	return signed || (n >= 0 && value[0] != '-')
+}
+
+func r16(lo, hi uint16) unicode.Range16 {
+	return unicode.Range16{Lo: lo, Hi: hi, Stride: 1}
+}
+
+func rangeTable(ranges ...unicode.Range16) *unicode.RangeTable {
+	t := &unicode.RangeTable{R16: ranges}
+	for _, r := range ranges {
+		if r.Hi <= unicode.MaxLatin1 {
+			t.LatinOffset++
+		}
+	}
+	return t
+}
@@ -0,0 +1,118 @@
+// parkjunwoo.com/microstral/pkg/flag/charset_test.go
+package flag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		flag  uint64
+		value string
+		ok    bool
+	}{
+		// 숫자 플래그
+		{"UINT", UINT, "123", true},
+		{"UINT negative", UINT, "-1", false},
+		{"INT", INT, "-12", true},
+		{"UNUM", UNUM, "1.5", true},
+		{"NUM", NUM, "-1.5", true},
+		{"OCT", OCT, "0o17", true},
+		{"OCT digit 8", OCT, "18", false},
+		{"HEX", HEX, "0xff", true},
+		{"HEX letter g", HEX, "fg", false},
+		{"BOOL", BOOL, "true", true},
+		{"BOOL word", BOOL, "yes", false},
+
+		// 문자 집합 플래그
+		{"SPCIAL", SPCIAL, "!@# -", true},
+		{"SPCIAL letter", SPCIAL, "a", false},
+		{"SPCIAL_EXT", SPCIAL_EXT, "«»·", true},
+		{"SPCIAL_EXT emoji", SPCIAL_EXT, "😀", false},
+		{"EMOJI", EMOJI, "😀👍", true},
+		{"EMOJI letter", EMOJI, "a", false},
+		{"UPPER", UPPER, "ABC", true},
+		{"UPPER lower", UPPER, "Abc", false},
+		{"LOWER", LOWER, "abc", true},
+		{"LOWER upper", LOWER, "abC", false},
+		{"LATIN", LATIN, "Café", true},
+		{"LATIN ext", LATIN, "Ā", false},
+		{"LATIN_EXT", LATIN_EXT, "ĀŁ", true},
+		{"LATIN_EXT ascii", LATIN_EXT, "a", false},
+		{"HEBREW", HEBREW, "שלום", true},
+		{"HEBREW latin", HEBREW, "a", false},
+		{"CYRILLIC", CYRILLIC, "Привет", true},
+		{"CYRILLIC latin", CYRILLIC, "P", false},
+		{"GREEK", GREEK, "Ελλάδα", true},
+		{"GREEK latin", GREEK, "a", false},
+		{"ARABIC", ARABIC, "مرحبا", true},
+		{"ARABIC latin", ARABIC, "a", false},
+		{"HANZI", HANZI, "漢字", true},
+		{"HANZI hangul", HANZI, "한", false},
+		{"HANZI_SIMPLE", HANZI_SIMPLE, "汉字", true},
+		{"HANZI_SIMPLE ext A", HANZI_SIMPLE, "㐀", false},
+		{"HANGUL", HANGUL, "한글", true},
+		{"HANGUL hanzi", HANGUL, "漢", false},
+		{"KATAKANA", KATAKANA, "カタカナー", true},
+		{"KATAKANA hiragana", KATAKANA, "ひ", false},
+		{"HIRAGANA", HIRAGANA, "ひらがな", true},
+		{"HIRAGANA katakana", HIRAGANA, "カ", false},
+		{"DEVANAGARI", DEVANAGARI, "नमस्ते", true},
+		{"DEVANAGARI latin", DEVANAGARI, "a", false},
+		{"ALPHA_NUM", ALPHA_NUM, "admin1", true},
+		{"ALPHA_HANGUL_NUM_SPECIAL", ALPHA_HANGUL_NUM_SPECIAL, "홍길동 Hong-1", true},
+
+		// 결합 문자
+		{"ZWJ after ASCII", ALPHA_NUM, "admin\u200d", false},
+		{"ZWJ without EMOJI", LATIN_ALL, "admin\u200d", false},
+		{"tag char without EMOJI", ALPHA_NUM, "admin\U000E0067", false},
+		{"variation selector without EMOJI", ALPHA, "a\ufe0f", false},
+		{"acute after ASCII", ALPHA_NUM, "admin\u0301", false},
+		{"acute after HANGUL", HANGUL, "한\u0301", false},
+		{"acute after LATIN", LATIN, "e\u0301", true},
+		{"Vietnamese stacked marks", LATIN_ALL, "e\u0323\u0302", true},
+		{"Greek tonos", GREEK, "α\u0301", true},
+		{"Cyrillic breve", CYRILLIC, "и\u0306", true},
+		{"Arabic harakat", ARABIC, "ب\u064e", true},
+		{"Hebrew points", HEBREW, "ש\u05b8\u05c1לו\u05b9ם", true},
+		{"Japanese dakuten", HIRAGANA, "か\u3099", true},
+		{"leading mark", LATIN, "\u0301a", false},
+		{"Zalgo", LATIN, "a" + strings.Repeat("\u0301", maxCombining+1), false},
+		{"marks up to limit", LATIN, "a" + strings.Repeat("\u0301", maxCombining), true},
+		{"emoji ZWJ family", EMOJI, "👨\u200d👩\u200d👧", true},
+		{"emoji tag flag", EMOJI, "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", true},
+		{"emoji variation selector", EMOJI, "❤\ufe0f", true},
+		{"keycap", UINT | EMOJI, "1\ufe0f\u20e3", true},
+
+		{"invalid UTF-8", ALPHA, "a\xff", false},
+		{"no flag", 0, "anything\u200d", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value, tt.flag)
+			if tt.ok && err != nil {
+				t.Errorf("Validate(%q) error = %v, want nil", tt.value, err)
+			}
+			if !tt.ok && err == nil {
+				t.Errorf("Validate(%q) = nil, want error", tt.value)
+			}
+		})
+	}
+}
+
+func TestValidateCharError(t *testing.T) {
+	err := Validate("ab\u200d", ALPHA)
+	var charErr *CharError
+	if !errors.As(err, &charErr) {
+		t.Fatalf("Validate() error = %v, want *CharError", err)
+	}
+	if charErr.Rune != 0x200D || charErr.Position != 2 {
+		t.Errorf("CharError = {%U, %d}, want {U+200D, 2}", charErr.Rune, charErr.Position)
+	}
+	if !errors.Is(err, ErrInvalidChar) {
+		t.Errorf("errors.Is(err, ErrInvalidChar) = false")
+	}
+}
@@ -0,0 +1,64 @@
+// parkjunwoo.com/microstral/pkg/flag/names.go
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 태그(param:"flag=HANGUL|NUM")에서 사용하는 플래그 이름
+var flagNames = map[string]uint64{
+	"BOOL": BOOL,
+	"UINT": UINT,
+	"INT":  INT,
+	"UNUM": UNUM,
+	"NUM":  NUM,
+	"OCT":  OCT,
+	"HEX":  HEX,
+
+	"SPCIAL":       SPCIAL,
+	"SPECIAL":      SPCIAL,
+	"SPCIAL_EXT":   SPCIAL_EXT,
+	"SPECIAL_EXT":  SPCIAL_EXT,
+	"EMOJI":        EMOJI,
+	"UPPER":        UPPER,
+	"LOWER":        LOWER,
+	"LATIN":        LATIN,
+	"LATIN_EXT":    LATIN_EXT,
+	"HEBREW":       HEBREW,
+	"CYRILLIC":     CYRILLIC,
+	"GREEK":        GREEK,
+	"ARABIC":       ARABIC,
+	"HANZI":        HANZI,
+	"HANZI_SIMPLE": HANZI_SIMPLE,
+	"HANGUL":       HANGUL,
+	"KATAKANA":     KATAKANA,
+	"HIRAGANA":     HIRAGANA,
+	"DEVANAGARI":   DEVANAGARI,
+
+	"ALPHA":     ALPHA,
+	"LATIN_ALL": LATIN_ALL,
+	"HANZI_ALL": HANZI_ALL,
+	"JAPANESE":  JAPANESE,
+
+	"ALPHA_NUM":                ALPHA_NUM,
+	"HANGUL_NUM":               HANGUL_NUM,
+	"ALPHA_HANGUL_NUM":         ALPHA_HANGUL_NUM,
+	"ALPHA_NUM_SPECIAL":        ALPHA_NUM_SPECIAL,
+	"HANGUL_NUM_SPECIAL":       HANGUL_NUM_SPECIAL,
+	"ALPHA_HANGUL_NUM_SPECIAL": ALPHA_HANGUL_NUM_SPECIAL,
+}
+
+// Parse는 "|"로 이어진 플래그 이름(대소문자 무시)을 하나의 플래그 값으로 합칩니다.
+// 예: "HANGUL|NUM", "alpha_num|special"
+func Parse(names string) (uint64, error) {
+	var f uint64
+	for _, name := range strings.Split(names, "|") {
+		v, ok := flagNames[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("unknown flag %q", name)
+		}
+		f |= v
+	}
+	return f, nil
+}
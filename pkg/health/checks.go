@@ -0,0 +1,41 @@
+// parkjunwoo.com/microstral/pkg/health/checks.go
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Pinger는 PingContext를 지원하는 연결입니다. (예: *sql.DB)
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping은 연결의 PingContext를 호출하는 체크를 생성합니다.
+func Ping(p Pinger) Check {
+	return p.PingContext
+}
+
+// HTTP는 url에 GET 요청을 보내 2xx 응답인지 확인하는 체크를 생성합니다.
+// client가 nil이면 http.DefaultClient를 사용합니다.
+func HTTP(client *http.Client, url string) Check {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return nil
+	}
+}
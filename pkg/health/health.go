@@ -0,0 +1,180 @@
+// parkjunwoo.com/microstral/pkg/health/health.go
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check는 의존성 하나의 상태를 확인합니다. 정상이면 nil을 반환합니다.
+// ctx에는 체크별 타임아웃이 걸려 있으므로 네트워크 호출에 그대로 전달해야 합니다.
+type Check func(ctx context.Context) error
+
+// Result는 체크 하나의 실행 결과입니다.
+type Result struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report는 모든 체크의 실행 결과입니다. 하나라도 실패하면 Status는 fail입니다.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type entry struct {
+	check   Check
+	timeout time.Duration
+}
+
+// Registry는 이름별 readiness 체크 목록입니다.
+type Registry struct {
+	mu       sync.RWMutex
+	checks   map[string]entry
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewRegistry는 체크별 기본 타임아웃이 timeout인 레지스트리를 생성합니다.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		checks:  make(map[string]entry),
+		timeout: timeout,
+	}
+}
+
+// Add는 기본 타임아웃으로 체크를 등록합니다. 같은 이름의 체크가 있으면 교체합니다.
+func (r *Registry) Add(name string, check Check) {
+	r.AddWithTimeout(name, r.timeout, check)
+}
+
+// AddWithTimeout은 지정한 타임아웃으로 체크를 등록합니다.
+func (r *Registry) AddWithTimeout(name string, timeout time.Duration, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = entry{check: check, timeout: timeout}
+}
+
+// SetDraining은 종료 중 여부를 설정합니다.
+// 종료 중이면 체크 결과와 관계없이 Run이 실패를 보고하여 로드밸런서가 트래픽을 끊도록 합니다.
+func (r *Registry) SetDraining(draining bool) {
+	r.draining.Store(draining)
+}
+
+// Remove는 체크를 삭제합니다.
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, name)
+}
+
+// Names는 등록된 체크 이름을 정렬하여 반환합니다.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run은 모든 체크를 동시에 실행하고 결과를 모읍니다.
+// 타임아웃을 지키지 않는 체크도 타임아웃이 지나면 실패로 처리합니다.
+func (r *Registry) Run(ctx context.Context) Report {
+	if r.draining.Load() {
+		return Report{Status: StatusFail, Checks: map[string]Result{
+			"shutdown": {Status: StatusFail, Error: "server is shutting down"},
+		}}
+	}
+	r.mu.RLock()
+	checks := make(map[string]entry, len(r.checks))
+	for name, e := range r.checks {
+		checks[name] = e
+	}
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, e := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := run(ctx, e)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// Handler는 체크 결과를 JSON으로 응답하는 핸들러입니다. 하나라도 실패하면 503을 반환합니다.
+func (r *Registry) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.Run(c.Request.Context())
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
+
+func run(ctx context.Context, e entry) Result {
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- fmt.Errorf("panic: %v", v)
+			}
+		}()
+		done <- e.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result := Result{
+		Status:     StatusOK,
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Error = fmt.Sprintf("timeout after %s", e.timeout)
+		}
+	}
+	return result
+}
@@ -0,0 +1,6 @@
+// parkjunwoo.com/microstral/pkg/listener/cloexec_other.go
+//go:build !unix
+
+package listener
+
+func closeOnExec(fd int) {}
@@ -0,0 +1,10 @@
+// parkjunwoo.com/microstral/pkg/listener/cloexec_unix.go
+//go:build unix
+
+package listener
+
+import "syscall"
+
+func closeOnExec(fd int) {
+	syscall.CloseOnExec(fd)
+}
@@ -0,0 +1,60 @@
+// parkjunwoo.com/microstral/pkg/listener/systemd.go
+package listener
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listenFdsStart는 systemd가 전달하는 첫 파일 디스크립터 번호입니다. (SD_LISTEN_FDS_START)
+const listenFdsStart = 3
+
+// Named는 systemd 소켓 유닛의 FileDescriptorName과 리스너입니다.
+type Named struct {
+	Name     string
+	Listener net.Listener
+}
+
+// Systemd는 systemd 소켓 활성화(LISTEN_PID, LISTEN_FDS, LISTEN_FDNAMES)로 전달된 리스너를 반환합니다.
+// 전달된 소켓이 없으면 nil을 반환합니다. 자식 프로세스에 다시 전달되지 않도록 환경 변수를 지웁니다.
+func Systemd() ([]Named, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, nil
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	listeners := make([]Named, 0, n)
+	for i := 0; i < n; i++ {
+		fd := listenFdsStart + i
+		closeOnExec(fd)
+
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		f := os.NewFile(uintptr(fd), name)
+		ln, err := net.FileListener(f)
+		// FileListener는 fd를 복제하므로 원본은 닫음
+		f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				l.Listener.Close()
+			}
+			return nil, fmt.Errorf("failed to use systemd socket %d (%s): %w", fd, name, err)
+		}
+		listeners = append(listeners, Named{Name: name, Listener: ln})
+	}
+	return listeners, nil
+}
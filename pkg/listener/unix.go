@@ -0,0 +1,56 @@
+// parkjunwoo.com/microstral/pkg/listener/unix.go
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Unix는 유닉스 도메인 소켓 path에서 대기하는 리스너를 생성하고 권한을 mode로 설정합니다.
+// 이전 프로세스가 남긴 소켓 파일이 있으면 사용 중인지 확인한 뒤 삭제합니다.
+func Unix(path string, mode os.FileMode) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to chmod %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	// 다른 프로세스가 대기 중인 소켓은 삭제하지 않음
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+	return os.Remove(path)
+}
+
+// ParseMode는 "0660" 형식의 8진수 파일 권한 문자열을 변환합니다.
+func ParseMode(value string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid socket mode %q: %w", value, err)
+	}
+	return os.FileMode(mode), nil
+}
@@ -0,0 +1,121 @@
+// parkjunwoo.com/microstral/pkg/logger/logger.go
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"parkjunwoo.com/microstral/pkg/env"
+)
+
+// ComponentKey는 로그를 남긴 패키지(컴포넌트) 이름을 담는 속성 키입니다.
+// 패키지별 로그 레벨은 이 속성 값을 기준으로 적용됩니다.
+const ComponentKey = "component"
+
+type ctxKey struct{}
+
+// New는 환경 변수 설정으로 slog 로거를 생성합니다.
+// - LOG_LEVEL: 기본 로그 레벨 (debug, info, warn, error / 기본값 info)
+// - LOG_FORMAT: json 또는 text (기본값 json)
+// - LOG_LEVELS: 패키지별 로그 레벨 예: "auth=debug,middleware=warn"
+func New(w io.Writer) *slog.Logger {
+	level := ParseLevel(env.GetEnv("LOG_LEVEL", "info"))
+	levels := ParseLevels(env.GetEnv("LOG_LEVELS", ""))
+
+	// 레벨 판단은 levelHandler가 하므로 내부 핸들러는 모든 레벨을 통과시킴
+	opts := &slog.HandlerOptions{Level: slog.Level(-1 << 10)}
+	var h slog.Handler
+	if strings.ToLower(env.GetEnv("LOG_FORMAT", "json")) == "text" {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(NewHandler(h, level, levels))
+}
+
+// NewHandler는 기본 레벨과 패키지별 레벨을 적용하는 핸들러로 h를 감쌉니다.
+func NewHandler(h slog.Handler, level slog.Leveler, levels map[string]slog.Level) slog.Handler {
+	return &levelHandler{handler: h, level: level, levels: levels}
+}
+
+// ParseLevel은 레벨 문자열을 slog.Level로 변환합니다. 알 수 없는 값은 info로 처리합니다.
+func ParseLevel(value string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// ParseLevels는 "auth=debug,middleware=warn" 형식의 패키지별 레벨 목록을 변환합니다.
+func ParseLevels(value string) map[string]slog.Level {
+	levels := make(map[string]slog.Level)
+	for _, entry := range strings.Split(value, ",") {
+		name, level, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" {
+			continue
+		}
+		levels[strings.TrimSpace(name)] = ParseLevel(level)
+	}
+	return levels
+}
+
+// Component는 컴포넌트 이름이 붙은 로거를 반환합니다.
+func Component(l *slog.Logger, name string) *slog.Logger {
+	return l.With(ComponentKey, name)
+}
+
+// NewContext는 요청 범위 로거를 컨텍스트에 저장합니다.
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext는 컨텍스트에 저장된 요청 범위 로거를 반환합니다.
+// 저장된 로거가 없으면 slog.Default()를 반환합니다.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok && l != nil {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// levelHandler는 component 속성에 따라 다른 최소 레벨을 적용합니다.
+type levelHandler struct {
+	handler   slog.Handler
+	level     slog.Leveler
+	levels    map[string]slog.Level
+	component string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minLevel := h.level.Level()
+	if l, ok := h.levels[h.component]; ok {
+		minLevel = l
+	}
+	return level >= minLevel
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey {
+			clone.component = attr.Value.String()
+		}
+	}
+	clone.handler = h.handler.WithAttrs(attrs)
+	return &clone
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.handler = h.handler.WithGroup(name)
+	return &clone
+}
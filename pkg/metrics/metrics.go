@@ -0,0 +1,103 @@
+// parkjunwoo.com/microstral/pkg/metrics/metrics.go
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+const namespace = "mist"
+
+// Registry는 microstral의 모든 메트릭이 등록되는 레지스트리입니다.
+// 애플리케이션 메트릭도 여기에 등록하면 같은 /metrics 엔드포인트로 노출됩니다.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests: 라우트/상태 코드별 요청 수
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration: 라우트별 요청 처리 시간
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// HTTPInFlight: 처리 중인 요청 수
+	HTTPInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	// Panics: 라우트별 복구된 패닉 수
+	Panics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_panics_total",
+		Help:      "Total number of panics recovered in HTTP handlers by route.",
+	}, []string{"route"})
+
+	// OPADecisions: OPA 정책 판단 결과 수 (allow, deny, error)
+	OPADecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "opa_decisions_total",
+		Help:      "Total number of OPA policy decisions by result.",
+	}, []string{"result"})
+
+	// TokenRefreshes: Cognito 토큰 재발급 결과 수 (success, failure)
+	TokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "auth_token_refresh_total",
+		Help:      "Total number of Cognito token refresh attempts by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		HTTPInFlight,
+		Panics,
+		OPADecisions,
+		TokenRefreshes,
+	)
+}
+
+// Handler는 Registry의 메트릭을 Prometheus 형식으로 노출하는 핸들러입니다.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDB는 sql.DB 연결 풀 통계(sql.DB.Stats)를 db_name 레이블로 등록합니다.
+// 같은 이름이 이미 등록되어 있으면 무시합니다.
+func RegisterDB(name string, db *sql.DB) error {
+	return register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterRedis는 go-redis 연결 풀 통계(PoolStats)를 name 레이블로 등록합니다.
+// 같은 이름이 이미 등록되어 있으면 무시합니다.
+func RegisterRedis(name string, client *redis.Client) error {
+	return register(newRedisCollector(name, client))
+}
+
+func register(c prometheus.Collector) error {
+	err := Registry.Register(c)
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
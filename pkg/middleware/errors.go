@@ -0,0 +1,24 @@
+// parkjunwoo.com/microstral/pkg/middleware/errors.go
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/problem"
+)
+
+// Errors는 핸들러가 c.Error 또는 problem.Abort로 기록한 오류를
+// RFC 9457 application/problem+json 응답으로 렌더링합니다.
+// 핸들러가 이미 응답을 쓴 경우에는 아무것도 하지 않습니다.
+// 오류 내용(내부 원인 포함)은 Logger 미들웨어의 접근 로그 errors 속성에 남습니다.
+// 다른 미들웨어의 오류도 렌더링하도록 RequestID 다음, 나머지 미들웨어보다 먼저 등록합니다.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		problem.Render(c, c.Errors.Last().Err)
+	}
+}
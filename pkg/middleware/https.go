@@ -0,0 +1,92 @@
+// parkjunwoo.com/microstral/pkg/middleware/https.go
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"parkjunwoo.com/microstral/pkg/env"
+)
+
+// ACMEChallengePath는 ACME HTTP-01 챌린지 경로입니다.
+const ACMEChallengePath = "/.well-known/acme-challenge/"
+
+// HSTS는 TLS로 들어온 요청의 응답에 Strict-Transport-Security 헤더를 추가합니다.
+// - HSTS_MAX_AGE: max-age 초 (기본값 31536000, 1년)
+// - HSTS_INCLUDE_SUBDOMAINS: includeSubDomains 추가 여부 (기본값 false)
+// - HSTS_PRELOAD: preload 추가 여부 (기본값 false)
+func HSTS() gin.HandlerFunc {
+	value := fmt.Sprintf("max-age=%d", env.GetEnvInt("HSTS_MAX_AGE", 31536000))
+	if env.GetEnvBool("HSTS_INCLUDE_SUBDOMAINS", false) {
+		value += "; includeSubDomains"
+	}
+	if env.GetEnvBool("HSTS_PRELOAD", false) {
+		value += "; preload"
+	}
+
+	return func(c *gin.Context) {
+		// 평문 HTTP 응답의 HSTS 헤더는 브라우저가 무시하므로 TLS 요청에만 추가
+		if c.Request.TLS != nil {
+			c.Header("Strict-Transport-Security", value)
+		}
+		c.Next()
+	}
+}
+
+// HTTPSRedirect는 모든 요청을 같은 경로/쿼리의 HTTPS 주소로 리다이렉트하는 핸들러입니다.
+// host가 비어 있으면 요청의 Host를 사용하고, port가 443이면 주소에서 포트를 생략합니다.
+// challenge가 nil이 아니면 ACME HTTP-01 챌린지 경로는 리다이렉트하지 않고 challenge로 처리합니다.
+func HTTPSRedirect(host string, port int, challenge http.Handler) http.Handler {
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := host
+		if target == "" {
+			target = r.Host
+			if h, _, err := net.SplitHostPort(r.Host); err == nil {
+				target = h
+			}
+		}
+		if port != 443 {
+			target = net.JoinHostPort(target, fmt.Sprint(port))
+		}
+		url := "https://" + target + r.URL.RequestURI()
+
+		// GET/HEAD 외의 요청은 메서드와 본문이 유지되도록 308 사용
+		code := http.StatusPermanentRedirect
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			code = http.StatusMovedPermanently
+		}
+		http.Redirect(w, r, url, code)
+	})
+	if challenge == nil {
+		return redirect
+	}
+	return ACMEChallenge(challenge, redirect)
+}
+
+// ACMEChallenge는 ACME HTTP-01 챌린지 경로는 challenge로, 나머지 요청은 next로 처리합니다.
+func ACMEChallenge(challenge http.Handler, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, ACMEChallengePath) {
+			challenge.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ACMEWebroot는 webroot 디렉터리의 챌린지 파일을 응답하는 핸들러입니다.
+// certbot --webroot 처럼 webroot/.well-known/acme-challenge/ 아래에 토큰을 쓰는 클라이언트와 함께 사용합니다.
+func ACMEWebroot(webroot string) http.Handler {
+	fs := http.FileServer(http.Dir(webroot))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 토큰 목록이 노출되지 않도록 디렉터리 요청은 거부
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		fs.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,137 @@
+// parkjunwoo.com/microstral/pkg/middleware/limit.go
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/problem"
+)
+
+// BodyLimit는 요청 본문 크기를 limit 바이트로 제한합니다.
+// Content-Length가 limit을 넘으면 413을 응답하고, 길이를 알 수 없는 본문은 limit까지만 읽을 수 있습니다.
+// 전역 제한과 함께 쓰면 작은 쪽이 적용되므로, 전역 제한을 라우트마다 바꾸려면 Limits를 사용합니다.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limitBody(c, limit) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// IsBodyTooLarge는 err가 BodyLimit 초과로 발생한 오류인지 확인합니다.
+// 핸들러가 본문 읽기 오류를 400 대신 413으로 응답할 때 사용합니다.
+func IsBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// Timeout은 요청 컨텍스트에 timeout 기한을 설정합니다.
+// 기한이 지나면 컨텍스트가 취소되어 DB, Redis, mttp 호출이 중단되고,
+// 핸들러가 아직 응답하지 않았으면 504를 응답합니다. (핸들러가 기록한 다른 오류보다 우선)
+// 전역 기한과 함께 쓰면 짧은 쪽이 적용되므로, 전역 기한을 라우트마다 바꾸려면 Limits를 사용합니다.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runWithTimeout(c, timeout)
+	}
+}
+
+// RouteLimit은 라우트 하나의 본문 크기 제한과 처리 기한입니다.
+// 0이면 Limits의 전역 값을 사용하고, 음수이면 제한하지 않습니다.
+type RouteLimit struct {
+	BodyBytes int64
+	Timeout   time.Duration
+}
+
+// Limits는 전역 본문 크기 제한과 처리 기한을 적용하고, Set으로 지정한 라우트에는 라우트의 값을 적용합니다.
+//
+//	limits := middleware.NewLimits(10<<20, 30*time.Second)
+//	router.Use(limits.Handler())
+//	limits.Set(http.MethodPost, "/v1/files", middleware.RouteLimit{BodyBytes: 1 << 30, Timeout: 10 * time.Minute})
+type Limits struct {
+	bodyBytes int64
+	timeout   time.Duration
+
+	mu     sync.RWMutex
+	routes map[string]RouteLimit // "METHOD /full/path" → 제한
+}
+
+// NewLimits는 전역 본문 크기 제한과 처리 기한(0이면 제한 없음)으로 Limits를 생성합니다.
+func NewLimits(bodyBytes int64, timeout time.Duration) *Limits {
+	return &Limits{bodyBytes: bodyBytes, timeout: timeout, routes: make(map[string]RouteLimit)}
+}
+
+// Set은 method와 라우트 전체 경로(gin 패턴, 예: "/v1/users/:id")의 제한을 지정합니다.
+func (l *Limits) Set(method, path string, limit RouteLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.routes[method+" "+path] = limit
+}
+
+// Handler는 요청의 라우트에 맞는 제한을 적용하는 미들웨어를 반환합니다.
+func (l *Limits) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bodyBytes, timeout := l.resolve(c.Request.Method, c.FullPath())
+		if bodyBytes > 0 && !limitBody(c, bodyBytes) {
+			return
+		}
+		if timeout > 0 {
+			runWithTimeout(c, timeout)
+			return
+		}
+		c.Next()
+	}
+}
+
+func (l *Limits) resolve(method, path string) (int64, time.Duration) {
+	bodyBytes, timeout := l.bodyBytes, l.timeout
+	if path == "" {
+		return bodyBytes, timeout
+	}
+	l.mu.RLock()
+	route, ok := l.routes[method+" "+path]
+	l.mu.RUnlock()
+	if ok {
+		if route.BodyBytes != 0 {
+			bodyBytes = route.BodyBytes
+		}
+		if route.Timeout != 0 {
+			timeout = route.Timeout
+		}
+	}
+	return bodyBytes, timeout
+}
+
+// limitBody는 본문 크기를 제한합니다. Content-Length가 limit을 넘으면 413으로 중단하고 false를 반환합니다.
+func limitBody(c *gin.Context, limit int64) bool {
+	if c.Request.ContentLength > limit {
+		problem.Abort(c, problem.New(http.StatusRequestEntityTooLarge, problem.CodeTooLarge,
+			fmt.Sprintf("request body must be at most %d bytes", limit)))
+		return false
+	}
+	if c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+	}
+	return true
+}
+
+// runWithTimeout은 요청 컨텍스트에 기한을 걸고 나머지 핸들러를 실행합니다.
+func runWithTimeout(c *gin.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Next()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+		problem.Abort(c, problem.New(http.StatusGatewayTimeout, problem.CodeTimeout,
+			fmt.Sprintf("request did not complete within %s", timeout)))
+	}
+}
@@ -0,0 +1,99 @@
+// parkjunwoo.com/microstral/pkg/middleware/limit_test.go
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/problem"
+)
+
+func TestTimeoutDeadlineExceeded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+	}{
+		{"no response", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+		}},
+		{"returns DeadlineExceeded", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			problem.Abort(c, fmt.Errorf("query users: %w", c.Request.Context().Err()))
+		}},
+		{"returns other error after deadline", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			problem.Abort(c, fmt.Errorf("pq: canceling statement due to user request"))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Errors(), Timeout(10*time.Millisecond))
+			r.GET("/", tt.handler)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != http.StatusGatewayTimeout {
+				t.Errorf("status = %d, want 504", w.Code)
+			}
+		})
+	}
+}
+
+func TestProblemFromDeadlineExceeded(t *testing.T) {
+	err := fmt.Errorf("call: %w", context.DeadlineExceeded)
+	if got := problem.From(err).Status; got != http.StatusGatewayTimeout {
+		t.Errorf("From(DeadlineExceeded).Status = %d, want 504", got)
+	}
+}
+
+func TestLimitsRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limits := NewLimits(8, 10*time.Millisecond)
+	limits.Set(http.MethodPost, "/upload/:id", RouteLimit{BodyBytes: 64, Timeout: -1})
+
+	r := gin.New()
+	r.Use(Errors(), limits.Handler())
+	read := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			problem.Abort(c, err)
+			return
+		}
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.String(http.StatusOK, fmt.Sprint(hasDeadline))
+	}
+	r.POST("/upload/:id", read)
+	r.POST("/small", read)
+
+	tests := []struct {
+		path     string
+		body     string
+		status   int
+		deadline string
+	}{
+		{"/upload/1", strings.Repeat("a", 32), http.StatusOK, "false"}, // 라우트 제한 64바이트, 기한 없음
+		{"/upload/1", strings.Repeat("a", 65), http.StatusRequestEntityTooLarge, ""},
+		{"/small", "abc", http.StatusOK, "true"}, // 전역 제한
+		{"/small", strings.Repeat("a", 9), http.StatusRequestEntityTooLarge, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.status {
+			t.Errorf("POST %s (%d bytes) status = %d, want %d", tt.path, len(tt.body), w.Code, tt.status)
+			continue
+		}
+		if tt.deadline != "" && w.Body.String() != tt.deadline {
+			t.Errorf("POST %s deadline = %s, want %s", tt.path, w.Body.String(), tt.deadline)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// parkjunwoo.com/microstral/pkg/middleware/logger.go
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"parkjunwoo.com/microstral/pkg/logger"
+)
+
+// Logger는 요청 범위 로거를 컨텍스트에 저장하고, 요청이 끝나면 접근 로그를 남깁니다.
+// 핸들러와 이후 미들웨어는 logger.FromContext(c.Request.Context())로 요청 범위 로거를 사용하며,
+// 이후 미들웨어가 추가한 속성(요청 ID, 사용자 ID 등)은 접근 로그에도 포함됩니다.
+func Logger(l *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		reqLogger := l.With(
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+		)
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		ctx := c.Request.Context()
+		attrs := []slog.Attr{
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		logger.Component(logger.FromContext(ctx), "access").LogAttrs(ctx, level, "request", attrs...)
+	}
+}
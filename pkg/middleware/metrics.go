@@ -0,0 +1,31 @@
+// parkjunwoo.com/microstral/pkg/middleware/metrics.go
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"parkjunwoo.com/microstral/pkg/metrics"
+)
+
+// Metrics는 라우트별 요청 수, 처리 시간, 처리 중인 요청 수를 기록합니다.
+// 라우트 레이블은 경로 파라미터가 치환되지 않은 등록 경로(c.FullPath)를 사용합니다.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.HTTPInFlight.Inc()
+		defer metrics.HTTPInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			// 등록되지 않은 경로는 레이블 폭증을 막기 위해 하나로 묶음
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
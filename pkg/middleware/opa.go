@@ -3,15 +3,25 @@ package middleware
 
 import (
 	"context"
-	"log"
-	"net/http"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 	"github.com/open-policy-agent/opa/v1/rego"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"parkjunwoo.com/microstral/pkg/auth"
 	"parkjunwoo.com/microstral/pkg/env"
 	"parkjunwoo.com/microstral/pkg/file"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/metrics"
+	"parkjunwoo.com/microstral/pkg/problem"
+	"parkjunwoo.com/microstral/pkg/telemetry"
 )
 
 var (
@@ -25,23 +35,37 @@ func initPolicyHotReload(path string) {
 		return
 	}
 
-	err := file.WatchFile(path, func(data []byte) {
+	// 정책은 프로세스가 끝날 때까지 감시
+	err := file.WatchFile(context.Background(), path, func(data []byte) {
 		policySrc.Store(string(data))
-		log.Printf("OPA policy reloaded: %s", path)
+		logger.Component(slog.Default(), "middleware").Info("OPA policy reloaded", "path", path)
 	})
 	if err != nil {
-		log.Fatalf("Failed to watch policy file: %v", err)
+		logger.Component(slog.Default(), "middleware").Error("failed to watch policy file", "path", path, "error", err)
+		os.Exit(1)
 	}
 }
 
+// OPAPolicyLoaded는 OPA 정책이 로드되었는지 확인하는 readiness 체크입니다.
+func OPAPolicyLoaded(ctx context.Context) error {
+	if policy, ok := policySrc.Load().(string); !ok || policy == "" {
+		return errors.New("OPA policy not loaded")
+	}
+	return nil
+}
+
 func OPA() gin.HandlerFunc {
 	path := env.GetEnv("OPA_POLICY", "policy.rego")
 	initPolicyHotReload(path)
 
 	return func(c *gin.Context) {
+		log := logger.Component(logger.FromContext(c.Request.Context()), "middleware")
+
 		policy, ok := policySrc.Load().(string)
 		if !ok || policy == "" {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "OPA policy load error"})
+			metrics.OPADecisions.WithLabelValues("error").Inc()
+			log.Error("OPA policy not loaded")
+			problem.Abort(c, problem.Internal(errors.New("OPA policy not loaded")))
 			return
 		}
 
@@ -56,30 +80,48 @@ func OPA() gin.HandlerFunc {
 			"groups":   claims.Groups,
 		}
 
-		ctx := context.Background()
+		ctx, span := otel.Tracer(telemetry.TracerName).Start(c.Request.Context(), "opa.eval",
+			trace.WithAttributes(attribute.String("opa.query", "data.httpapi.allow")),
+		)
+
 		query, err := rego.New(
 			rego.Query("data.httpapi.allow"),
 			rego.Module("policy.rego", policy),
 			rego.Input(input),
 		).PrepareForEval(ctx)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "OPA policy error"})
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+			metrics.OPADecisions.WithLabelValues("error").Inc()
+			log.Error("OPA policy error", "error", err)
+			problem.Abort(c, problem.Internal(fmt.Errorf("OPA policy error: %w", err)))
 			return
 		}
 
 		rs, err := query.Eval(ctx)
 		if err != nil || len(rs) == 0 {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "OPA eval error"})
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+			metrics.OPADecisions.WithLabelValues("error").Inc()
+			log.Warn("OPA eval error", "error", err)
+			problem.Abort(c, problem.Forbidden("policy_error", "access policy could not be evaluated").WithCause(err))
 			return
 		}
 
 		allowed, ok := rs[0].Expressions[0].Value.(bool)
+		span.SetAttributes(attribute.Bool("opa.allowed", ok && allowed))
+		span.End()
 		if !ok || !allowed {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "OPA denied"})
+			metrics.OPADecisions.WithLabelValues("deny").Inc()
+			log.Info("OPA denied", "path", c.Request.URL.Path, "username", claims.ID)
+			problem.Abort(c, problem.Forbidden(problem.CodeForbidden, "access denied by policy"))
 			return
 		}
 
 		// 통과
+		metrics.OPADecisions.WithLabelValues("allow").Inc()
 		c.Next()
 	}
 }
@@ -2,13 +2,14 @@
 package middleware
 
 import (
-	"log"
-	"net/http"
+	"log/slog"
 	"regexp"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/problem"
 )
 
 func Origin() gin.HandlerFunc {
@@ -29,7 +30,7 @@ func Origin() gin.HandlerFunc {
 		var err error
 		re, err = regexp.Compile(pattern)
 		if err != nil {
-			log.Printf("failed to compile ALLOWED_ORIGIN pattern: %v", err)
+			logger.Component(slog.Default(), "middleware").Error("failed to compile ALLOWED_ORIGIN pattern", "error", err)
 			// 오류가 발생하면 미들웨어를 빈 함수로 반환
 			return func(c *gin.Context) {
 				c.Next()
@@ -40,7 +41,7 @@ func Origin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestOrigin := c.Request.Header.Get("Origin")
 		if requestOrigin == "" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+			problem.Abort(c, problem.Forbidden("origin_not_allowed", "origin not allowed"))
 			return
 		}
 		allowed := false
@@ -58,7 +59,7 @@ func Origin() gin.HandlerFunc {
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 			c.Next()
 		} else {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+			problem.Abort(c, problem.Forbidden("origin_not_allowed", "origin not allowed"))
 			return
 		}
 	}
@@ -0,0 +1,111 @@
+// parkjunwoo.com/microstral/pkg/middleware/recovery.go
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/auth"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/metrics"
+	"parkjunwoo.com/microstral/pkg/problem"
+	"parkjunwoo.com/microstral/pkg/report"
+	"parkjunwoo.com/microstral/pkg/requestid"
+)
+
+// reportTimeout은 패닉 이벤트 전송 제한 시간입니다.
+const reportTimeout = 5 * time.Second
+
+// Recovery는 핸들러의 패닉을 복구하여 500 application/problem+json으로 응답합니다.
+// 패닉 값과 스택을 요청 ID, 라우트, 사용자와 함께 로그에 남기고 mist_http_panics_total을 증가시키며,
+// reporter가 nil이 아니면 이벤트를 비동기로 전달합니다.
+// 요청 ID가 로그에 남고 접근 로그/메트릭에 500이 기록되도록 Logger, RequestID 미들웨어 다음에 등록합니다.
+func Recovery(reporter report.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// net/http가 응답을 중단하도록 던진 패닉은 그대로 전달
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			ctx := c.Request.Context()
+			log := logger.Component(logger.FromContext(ctx), "middleware")
+			if err, ok := rec.(error); ok && isConnReset(err) {
+				// 클라이언트가 연결을 끊어 응답을 쓸 수 없는 경우는 장애가 아님
+				log.Warn("client connection closed", "error", err)
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			route := c.FullPath()
+			claims := auth.GetClaims(c)
+			// method, route, request_id는 요청 범위 로거에 이미 포함됨
+			log.Error("panic recovered",
+				"path", c.Request.URL.Path,
+				"user_id", claims.ID,
+				"panic", fmt.Sprint(rec),
+				"stack", string(debug.Stack()),
+			)
+			if route == "" {
+				route = "unmatched"
+			}
+			metrics.Panics.WithLabelValues(route).Inc()
+
+			if reporter != nil {
+				event := report.NewPanicEvent(rec, 1)
+				event.Logger = "microstral"
+				event.Transaction = c.Request.Method + " " + route
+				event.Request = &report.Request{
+					Method:      c.Request.Method,
+					URL:         requestURL(c.Request),
+					QueryString: c.Request.URL.RawQuery,
+				}
+				event.User = &report.User{ID: claims.ID, Email: claims.Email}
+				if ids, ok := requestid.FromContext(ctx); ok {
+					event.Tags["request_id"] = ids.RequestID
+				}
+				// 응답을 지연시키지 않도록 요청이 끝나도 취소되지 않는 컨텍스트로 전송
+				go func() {
+					rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reportTimeout)
+					defer cancel()
+					if err := reporter.Report(rctx, event); err != nil {
+						log.Warn("failed to report panic", "event_id", event.EventID, "error", err)
+					}
+				}()
+			}
+
+			err := problem.Internal(fmt.Errorf("panic: %v", rec))
+			c.Error(err)
+			c.Abort()
+			if !c.Writer.Written() {
+				problem.Render(c, err)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+func isConnReset(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
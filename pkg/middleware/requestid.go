@@ -0,0 +1,39 @@
+// parkjunwoo.com/microstral/pkg/middleware/requestid.go
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"parkjunwoo.com/microstral/pkg/logger"
+	"parkjunwoo.com/microstral/pkg/requestid"
+)
+
+// RequestID는 X-Request-ID와 traceparent를 받아들이거나 생성하여
+// gin 컨텍스트("request_id", "traceparent")와 요청 컨텍스트에 저장하고, 응답 헤더로 돌려줍니다.
+// 요청 범위 로거에도 request_id, trace_id 속성을 추가합니다.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.HeaderRequestID)
+		if !requestid.Valid(id) {
+			id = requestid.Generate()
+		}
+		// Tracing 미들웨어가 스팬을 시작했으면 그 스팬을 부모로 하는 traceparent 사용
+		var traceparent string
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			traceparent = "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-" + sc.TraceFlags().String()
+		} else {
+			traceparent = requestid.Traceparent(c.GetHeader(requestid.HeaderTraceparent))
+		}
+		traceID, _, _ := requestid.ParseTraceparent(traceparent)
+
+		c.Set("request_id", id)
+		c.Set("traceparent", traceparent)
+		c.Header(requestid.HeaderRequestID, id)
+
+		ctx := requestid.NewContext(c.Request.Context(), requestid.IDs{RequestID: id, Traceparent: traceparent})
+		ctx = logger.NewContext(ctx, logger.FromContext(ctx).With("request_id", id, "trace_id", traceID))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
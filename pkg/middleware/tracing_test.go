@@ -0,0 +1,95 @@
+// parkjunwoo.com/microstral/pkg/middleware/tracing_test.go
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"parkjunwoo.com/microstral/pkg/problem"
+	"parkjunwoo.com/microstral/pkg/telemetry"
+)
+
+func TestTracingRecordsSpan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	t.Cleanup(func() { tp.Shutdown(t.Context()) })
+	telemetry.Install(tp)
+
+	r := gin.New()
+	r.Use(Tracing(), Errors())
+	r.GET("/users/:id", func(c *gin.Context) {
+		if c.Param("id") == "broken" {
+			problem.Abort(c, problem.Internal(errors.New("broken")))
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name        string
+		path        string
+		traceparent string
+		spanName    string
+		route       string
+		status      int
+		code        codes.Code
+	}{
+		{"route", "/users/1", "", "GET /users/:id", "/users/:id", http.StatusOK, codes.Unset},
+		{"remote parent", "/users/1", traceparent, "GET /users/:id", "/users/:id", http.StatusOK, codes.Unset},
+		{"server error", "/users/broken", "", "GET /users/:id", "/users/:id", http.StatusInternalServerError, codes.Error},
+		{"no route", "/missing", "", "GET", "", http.StatusNotFound, codes.Unset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp.Reset()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			spans := exp.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name != tt.spanName {
+				t.Errorf("span name = %q, want %q", span.Name, tt.spanName)
+			}
+			if span.SpanKind != trace.SpanKindServer {
+				t.Errorf("span kind = %v, want server", span.SpanKind)
+			}
+			if span.Status.Code != tt.code {
+				t.Errorf("span status = %v, want %v", span.Status.Code, tt.code)
+			}
+			attrs := attribute.NewSet(span.Attributes...)
+			if v, _ := attrs.Value(semconv.HTTPRouteKey); v.AsString() != tt.route {
+				t.Errorf("http.route = %q, want %q", v.AsString(), tt.route)
+			}
+			if v, _ := attrs.Value(semconv.HTTPResponseStatusCodeKey); v.AsInt64() != int64(tt.status) {
+				t.Errorf("http.response.status_code = %d, want %d", v.AsInt64(), tt.status)
+			}
+			if tt.traceparent != "" {
+				if got := span.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+					t.Errorf("trace id = %s, want parent trace id", got)
+				}
+				if got := span.Parent.SpanID().String(); got != "00f067aa0ba902b7" || !span.Parent.IsRemote() {
+					t.Errorf("parent span = %s (remote %v), want remote 00f067aa0ba902b7", got, span.Parent.IsRemote())
+				}
+			}
+		})
+	}
+}
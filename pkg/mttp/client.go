@@ -3,11 +3,16 @@ package mttp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"parkjunwoo.com/microstral/pkg/requestid"
 )
 
 type Response http.Response
@@ -21,11 +26,20 @@ func NewClient() *Client {
 	return &Client{
 		client: &http.Client{
 			Timeout: 10 * time.Second,
+			// 전역 TracerProvider가 등록되어 있으면 클라이언트 스팬을 만들고 traceparent를 전파
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
 	}
 }
 
 func (c *Client) Request(method string, url string, endpoint string, body interface{}, headers map[string]string) (*Response, error) {
+	return c.RequestContext(context.Background(), method, url, endpoint, body, headers)
+}
+
+// RequestContext는 ctx로 요청을 보냅니다.
+// ctx에 수신 요청의 상관관계 식별자(requestid)가 있으면 X-Request-ID와 traceparent 헤더로 전달합니다.
+// headers에 같은 헤더가 지정되어 있으면 headers 값이 우선합니다.
+func (c *Client) RequestContext(ctx context.Context, method string, url string, endpoint string, body interface{}, headers map[string]string) (*Response, error) {
 	// 요청 바디 생성
 	var bodyReader io.Reader
 	if body != nil {
@@ -37,11 +51,21 @@ func (c *Client) Request(method string, url string, endpoint string, body interf
 	}
 
 	// HTTP 요청 생성
-	req, err := http.NewRequest(method, url+endpoint, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url+endpoint, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("fail to Create HTTP Request: %v", err)
 	}
 
+	// 상관관계 식별자 전달
+	if ids, ok := requestid.FromContext(ctx); ok {
+		if ids.RequestID != "" {
+			req.Header.Set(requestid.HeaderRequestID, ids.RequestID)
+		}
+		if ids.Traceparent != "" {
+			req.Header.Set(requestid.HeaderTraceparent, ids.Traceparent)
+		}
+	}
+
 	// 헤더 적용
 	for key, value := range headers {
 		req.Header.Set(key, value)
@@ -0,0 +1,658 @@
+// parkjunwoo.com/microstral/pkg/param/bind.go
+package param
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"parkjunwoo.com/microstral/pkg/flag"
+)
+
+// 필드 오류 코드
+const (
+	CodeRequired    = "required"
+	CodeTooShort    = "too_short"
+	CodeTooLong     = "too_long"
+	CodeInvalid     = "invalid"
+	CodeInvalidType = "invalid_type"
+	CodeOutOfRange  = "out_of_range"
+	CodeStep        = "step_mismatch"
+	CodeNotAllowed  = "not_allowed"
+	CodeChecksum    = "checksum_mismatch"
+	CodeBirthDate   = "invalid_birth_date"
+	CodeCardLength  = "invalid_length"
+	CodeCardBrand   = "unsupported_card_brand"
+)
+
+// 멀티파트 폼을 읽을 때 메모리에 둘 최대 크기 (초과분은 임시 파일)
+const maxMultipartMemory = 32 << 20
+
+// FieldError는 요청 필드 하나의 검증 오류입니다.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationErrors는 Bind가 반환하는 필드별 검증 오류 목록입니다.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, f := range e {
+		parts[i] = f.Field + ": " + f.Code
+	}
+	return "validation failed: " + strings.Join(parts, ", ")
+}
+
+// BindError는 요청 본문을 읽거나 해석하지 못한 오류입니다.
+type BindError struct {
+	Err error
+}
+
+func (e *BindError) Error() string {
+	return "failed to bind request: " + e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// Bind는 input을 Parse로 검증하고 정규화한 값을 반환합니다. 실패하면 필드 하나의 오류를 담은
+// ValidationErrors를 반환하므로 컨트롤러에서 problem.Abort(c, err)로 그대로 응답할 수 있습니다.
+func (p *Param) Bind(input string) (string, error) {
+	valid, err := p.Validate(input)
+	if err != nil {
+		return "", ValidationErrors{fieldError(p.Name, err)}
+	}
+	if !valid {
+		return "", ValidationErrors{{Field: p.Name, Code: CodeInvalid, Message: fmt.Sprintf("%s is not a valid %s", p.Name, TypeName(p.Type))}}
+	}
+	value, err := p.Parse(input)
+	if err != nil {
+		return "", ValidationErrors{fieldError(p.Name, err)}
+	}
+	return value, nil
+}
+
+// Check는 input을 검증만 하고, 실패하면 Bind와 같은 ValidationErrors를 반환합니다.
+func (p *Param) Check(input string) error {
+	_, err := p.Bind(input)
+	return err
+}
+
+// fieldError는 Validate의 오류를 오류 코드가 있는 필드 오류로 변환합니다.
+func fieldError(name string, err error) FieldError {
+	if errors.Is(err, ErrRequired) {
+		return FieldError{Field: name, Code: CodeRequired, Message: name + " is required"}
+	}
+	for _, c := range []struct {
+		err  error
+		code string
+	}{
+		{ErrTooShort, CodeTooShort},
+		{ErrTooLong, CodeTooLong},
+		{ErrOutOfRange, CodeOutOfRange},
+		{ErrStep, CodeStep},
+		{ErrNotAllowed, CodeNotAllowed},
+	} {
+		if errors.Is(err, c.err) {
+			// "value is too long: must be at most 64 characters" → "name must be at most 64 characters"
+			detail := strings.TrimPrefix(err.Error(), c.err.Error()+": ")
+			return FieldError{Field: name, Code: c.code, Message: name + " " + detail}
+		}
+	}
+	code := CodeInvalid
+	switch {
+	case errors.Is(err, ErrChecksum):
+		code = CodeChecksum
+	case errors.Is(err, ErrBirthDate):
+		code = CodeBirthDate
+	case errors.Is(err, ErrCardLength):
+		code = CodeCardLength
+	case errors.Is(err, ErrCardBrand):
+		code = CodeCardBrand
+	}
+	return FieldError{Field: name, Code: code, Message: fmt.Sprintf("%s is invalid: %v", name, err)}
+}
+
+// Bind는 요청의 경로 파라미터(uri 태그), 쿼리(form 태그), 본문(JSON 또는 폼)을 dst 구조체에 채우고
+// binding 태그와 param 태그로 검증합니다. 경로 파라미터는 쿼리나 본문의 같은 이름 값보다 우선합니다.
+//
+//	type PostUserRequest struct {
+//		ID   string `json:"id" param:"type=EMAIL,required,max=256"`
+//		Name string `json:"name" param:"type=NAME_KR,required,max=64"`
+//	}
+//
+// param 태그 옵션 (각 옵션은 Param의 같은 이름 필드에 대응):
+//   - type=NAME: 등록된 타입 이름(EMAIL, NAME_KR, UUID 등)의 검증 함수로 검사
+//   - flag=NAME|NAME: 플래그 이름으로 문자 집합 또는 숫자 형식 검사 (예: flag=HANGUL|NUM, flag=UINT)
+//   - required: 빈 값 거부
+//   - min=N, max=N: 글자 수(rune) 범위
+//   - minbytes=N, maxbytes=N: 바이트 수 범위
+//   - gte=N, lte=N, step=N: 숫자 범위와 간격 (숫자 필드에 flag가 없으면 필드 타입으로 정함)
+//   - enum=a|b|c: 허용 값 목록
+//   - enumfold: enum을 대소문자 구분 없이 비교하고 enum에 적힌 값으로 바꿈
+//   - default=V: 값이 없을 때 채울 기본값 (숫자/불리언 필드는 0 값이면 적용하므로 0을 구분하려면 포인터 사용)
+//   - name=NAME: 오류에 표시할 필드 이름 (기본값: json, form, uri 태그 또는 필드 이름)
+//
+// 검증을 통과한 문자열 필드는 타입의 정규화 함수로 정규 형식으로 바꿉니다. (예: EMAIL 소문자, MOBILE_KR E.164)
+// 검증에 실패하면 모든 필드 오류를 담은 ValidationErrors를, 본문을 해석하지 못하면 *BindError를 반환합니다.
+func Bind(c *gin.Context, dst any) error {
+	rules, err := rulesFor(dst)
+	if err != nil {
+		return err
+	}
+
+	if err := binding.MapFormWithTag(dst, rules.formValues(c.Request.URL.Query()), "form"); err != nil {
+		return &BindError{Err: err}
+	}
+	if err := bindBody(c.Request, dst, rules); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return ValidationErrors{{
+				Field:   typeErr.Field,
+				Code:    CodeInvalidType,
+				Message: fmt.Sprintf("%s must be %s", typeErr.Field, typeErr.Type),
+			}}
+		}
+		return &BindError{Err: err}
+	}
+	// 경로 파라미터는 쿼리나 본문에 같은 이름이 있어도 덮어쓰지 못하도록 마지막에 채움
+	if len(c.Params) > 0 {
+		uri := make(map[string][]string, len(c.Params))
+		for _, p := range c.Params {
+			uri[p.Key] = []string{p.Value}
+		}
+		if err := binding.MapFormWithTag(dst, uri, "uri"); err != nil {
+			return &BindError{Err: err}
+		}
+	}
+
+	if err := rules.applyDefaults(reflect.ValueOf(dst).Elem()); err != nil {
+		return &BindError{Err: err}
+	}
+	errs := validateStruct(dst, rules)
+	errs = append(errs, rules.validate(reflect.ValueOf(dst).Elem(), errs)...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return rules.normalize(reflect.ValueOf(dst).Elem())
+}
+
+// bindBody는 Content-Type에 따라 요청 본문을 dst에 채웁니다. 빈 본문은 무시합니다.
+func bindBody(r *http.Request, dst any, rules *structRules) error {
+	if r.Body == nil || r.Body == http.NoBody || r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return nil
+	}
+
+	switch contentType := filterFlags(r.Header.Get("Content-Type")); {
+	case contentType == gin.MIMEJSON || strings.HasSuffix(contentType, "+json"):
+		err := json.NewDecoder(r.Body).Decode(dst)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	case contentType == gin.MIMEMultipartPOSTForm:
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(dst, rules.formValues(r.PostForm), "form")
+	case contentType == gin.MIMEPOSTForm:
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(dst, rules.formValues(r.PostForm), "form")
+	default:
+		return nil
+	}
+}
+
+func filterFlags(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// validateStruct는 gin의 binding 태그 검증 결과를 필드 오류로 변환합니다.
+func validateStruct(dst any, rules *structRules) ValidationErrors {
+	if binding.Validator == nil {
+		return nil
+	}
+	err := binding.Validator.ValidateStruct(dst)
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return ValidationErrors{{Field: "", Code: CodeInvalid, Message: err.Error()}}
+	}
+	errs := make(ValidationErrors, 0, len(verrs))
+	for _, fe := range verrs {
+		name := rules.names[fe.StructField()]
+		if name == "" {
+			name = fe.Field()
+		}
+		code := fe.Tag()
+		message := fmt.Sprintf("%s failed %s validation", name, code)
+		if code == CodeRequired {
+			message = name + " is required"
+		}
+		errs = append(errs, FieldError{Field: name, Code: code, Message: message})
+	}
+	return errs
+}
+
+// structRules는 구조체 타입별로 해석한 param 태그입니다.
+type structRules struct {
+	fields []fieldRule
+	// 구조체 필드 이름 → 오류에 표시할 이름
+	names map[string]string
+	// form 태그가 없는 필드의 외부 이름 → 구조체 필드 이름
+	formAliases map[string]string
+}
+
+// formValues는 form 태그 없이 json 태그만 있는 필드도 폼/쿼리 값을 받도록
+// 외부 이름의 값을 구조체 필드 이름으로 복사합니다. (gin은 form 태그가 없으면 필드 이름으로 찾음)
+func (r *structRules) formValues(form map[string][]string) map[string][]string {
+	if len(r.formAliases) == 0 || len(form) == 0 {
+		return form
+	}
+	values := make(map[string][]string, len(form))
+	for key, v := range form {
+		values[key] = v
+	}
+	for name, fieldName := range r.formAliases {
+		if v, ok := form[name]; ok {
+			if _, exists := form[fieldName]; !exists {
+				values[fieldName] = v
+			}
+		}
+	}
+	return values
+}
+
+type fieldRule struct {
+	index []int
+	name  string
+	param Param
+}
+
+var rulesCache sync.Map // reflect.Type → *structRules
+
+func rulesFor(dst any) (*structRules, error) {
+	t := reflect.TypeOf(dst)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("param: Bind requires a pointer to a struct, got %T", dst)
+	}
+	t = t.Elem()
+	if cached, ok := rulesCache.Load(t); ok {
+		return cached.(*structRules), nil
+	}
+
+	rules := &structRules{names: map[string]string{}, formAliases: map[string]string{}}
+	if err := rules.parse(t, nil); err != nil {
+		return nil, err
+	}
+	rulesCache.Store(t, rules)
+	return rules, nil
+}
+
+func (r *structRules) parse(t reflect.Type, parent []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		// 임베디드 구조체의 필드는 바깥 구조체의 필드로 취급
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := r.parse(field.Type, index); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		rule := fieldRule{index: index, name: fieldName(field), param: Param{Type: FLAG}}
+		tag, ok := field.Tag.Lookup("param")
+		for _, opt := range strings.Split(tag, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+			var err error
+			switch key {
+			case "":
+			case "type":
+				typ, found := TypeByName(value)
+				if !found {
+					return fmt.Errorf("param: unknown type %q on field %s", value, field.Name)
+				}
+				rule.param.Type = typ
+			case "flag":
+				rule.param.Flag, err = flag.Parse(value)
+			case "required":
+				rule.param.Required = true
+			case "min":
+				rule.param.MinLength, err = strconv.Atoi(value)
+			case "max":
+				rule.param.MaxLength, err = strconv.Atoi(value)
+			case "minbytes":
+				rule.param.MinBytes, err = strconv.Atoi(value)
+			case "maxbytes":
+				rule.param.MaxBytes, err = strconv.Atoi(value)
+			case "gte":
+				rule.param.Min, err = parseFloat(value)
+			case "lte":
+				rule.param.Max, err = parseFloat(value)
+			case "step":
+				rule.param.Step, err = strconv.ParseFloat(value, 64)
+			case "enum":
+				rule.param.Enum = strings.Split(value, "|")
+			case "enumfold":
+				rule.param.EnumFold = true
+			case "default":
+				rule.param.Default = value
+			case "name":
+				rule.name = value
+			default:
+				return fmt.Errorf("param: unknown option %q on field %s", key, field.Name)
+			}
+			if err != nil {
+				return fmt.Errorf("param: invalid %s on field %s: %w", key, field.Name, err)
+			}
+		}
+		rule.param.Name = rule.name
+		if err := rule.resolve(field.Type); err != nil {
+			return fmt.Errorf("param: field %s: %w", field.Name, err)
+		}
+		r.names[field.Name] = rule.name
+		// uri 태그가 있는 필드는 경로 파라미터로만 받음 (쿼리/폼으로 바꿔치기 방지)
+		_, hasForm := field.Tag.Lookup("form")
+		_, hasURI := field.Tag.Lookup("uri")
+		if !hasForm && !hasURI && rule.name != field.Name {
+			r.formAliases[rule.name] = field.Name
+		}
+		if ok {
+			r.fields = append(r.fields, rule)
+		}
+	}
+	return nil
+}
+
+// fieldName은 json, form, uri 태그 순서로 필드의 외부 이름을 찾습니다.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// validate는 param 태그 규칙으로 필드를 검증합니다. skip에 이미 오류가 있는 필드는 건너뜁니다.
+func (r *structRules) validate(v reflect.Value, skip ValidationErrors) ValidationErrors {
+	failed := make(map[string]bool, len(skip))
+	for _, e := range skip {
+		failed[e.Field] = true
+	}
+
+	var errs ValidationErrors
+	for _, rule := range r.fields {
+		if failed[rule.name] {
+			continue
+		}
+		values, present := fieldValues(v.FieldByIndex(rule.index))
+		if !present {
+			if rule.param.Required {
+				errs = append(errs, FieldError{Field: rule.name, Code: CodeRequired, Message: rule.name + " is required"})
+			}
+			continue
+		}
+		for _, value := range values {
+			if e, ok := rule.check(value); !ok {
+				errs = append(errs, e)
+				break
+			}
+		}
+	}
+	return errs
+}
+
+func (rule fieldRule) check(value string) (FieldError, bool) {
+	valid, err := rule.param.Validate(value)
+	if err != nil {
+		return fieldError(rule.name, err), false
+	}
+	if !valid {
+		return FieldError{Field: rule.name, Code: CodeInvalid, Message: fmt.Sprintf("%s is not a valid %s", rule.name, TypeName(rule.param.Type))}, false
+	}
+	return FieldError{}, true
+}
+
+// resolve는 태그로 만든 Param을 필드 타입에 맞게 보완하고 모순된 설정을 거부합니다.
+func (rule *fieldRule) resolve(t reflect.Type) error {
+	p := &rule.param
+	ranged := p.Min != nil || p.Max != nil || p.Step != 0
+	if ranged && p.Type == FLAG && p.Flag == 0 {
+		p.Flag = numericFlag(t)
+	}
+	if ranged && (p.Type != FLAG || !flag.IsNumeric(p.Flag)) {
+		return errors.New("gte, lte and step require a numeric flag")
+	}
+	if p.Step < 0 {
+		return errors.New("step must be positive")
+	}
+	if p.Default != "" {
+		if _, err := p.Validate(p.Default); err != nil {
+			return fmt.Errorf("invalid default %q: %w", p.Default, err)
+		}
+	}
+	return nil
+}
+
+// numericFlag는 필드 타입에 맞는 숫자 플래그를 반환합니다. 숫자 타입이 아니면 0입니다.
+func numericFlag(t reflect.Type) uint64 {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return flag.INT
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return flag.UINT
+	case reflect.Float32, reflect.Float64:
+		return flag.NUM
+	default:
+		return 0
+	}
+}
+
+func parseFloat(value string) (*float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// applyDefaults는 값이 없는 필드에 default 옵션의 값을 채웁니다.
+func (r *structRules) applyDefaults(v reflect.Value) error {
+	for _, rule := range r.fields {
+		if rule.param.Default == "" {
+			continue
+		}
+		if err := setDefault(v.FieldByIndex(rule.index), rule.param.Default); err != nil {
+			return fmt.Errorf("default of %s: %w", rule.name, err)
+		}
+	}
+	return nil
+}
+
+func setDefault(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := setScalar(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		if v.Len() > 0 {
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setScalar(elem, value); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(reflect.MakeSlice(v.Type(), 0, 1), elem))
+	default:
+		if !v.IsZero() {
+			return nil
+		}
+		return setScalar(v, value)
+	}
+	return nil
+}
+
+func setScalar(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// normalize는 정규화 함수가 있는 타입의 문자열 필드를 정규 형식으로 바꿉니다.
+func (r *structRules) normalize(v reflect.Value) error {
+	var errs ValidationErrors
+	for _, rule := range r.fields {
+		if rule.param.Type == FLAG || rule.param.Type == REGEX {
+			continue
+		}
+		fn, ok := lookupNormalizeFunc(rule.param.Type)
+		if !ok {
+			continue
+		}
+		if err := normalizeField(v.FieldByIndex(rule.index), fn); err != nil {
+			errs = append(errs, fieldError(rule.name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func normalizeField(v reflect.Value, fn NormalizeFunc) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return normalizeField(v.Elem(), fn)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := normalizeField(v.Index(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+		s, err := fn(v.String())
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	}
+	return nil
+}
+
+// fieldValues는 검증할 문자열 값 목록을 반환합니다. nil 포인터/슬라이스는 값이 없는 것으로 봅니다.
+func fieldValues(v reflect.Value) ([]string, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, false
+		}
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, ok := scalarString(v.Index(i))
+			if ok {
+				values = append(values, s)
+			}
+		}
+		return values, v.Len() > 0
+	default:
+		s, ok := scalarString(v)
+		return []string{s}, ok
+	}
+}
+
+func scalarString(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,53 @@
+// parkjunwoo.com/microstral/pkg/param/bind_test.go
+package param
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bindPathRequest struct {
+	ID   string `uri:"id" json:"-" param:"type=EMAIL,required,maxbytes=256"`
+	Name string `json:"name"`
+}
+
+func TestBindPathParamWins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+	}{
+		{"query alias", "/users/alice@example.com?id=bob@example.com", "", ""},
+		{"query field name", "/users/alice@example.com?ID=bob@example.com", "", ""},
+		{"form body", "/users/alice@example.com", gin.MIMEPOSTForm, "id=bob@example.com&ID=bob@example.com&name=x"},
+		{"json body", "/users/alice@example.com", gin.MIMEJSON, `{"id":"bob@example.com","ID":"bob@example.com"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bindPathRequest
+			var bindErr error
+			r := gin.New()
+			r.PUT("/users/:id", func(c *gin.Context) {
+				bindErr = Bind(c, &got)
+			})
+			req := httptest.NewRequest(http.MethodPut, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if bindErr != nil {
+				t.Fatalf("Bind() error = %v", bindErr)
+			}
+			if got.ID != "alice@example.com" {
+				t.Errorf("ID = %q, want path value alice@example.com", got.ID)
+			}
+		})
+	}
+}
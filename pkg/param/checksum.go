@@ -0,0 +1,191 @@
+// parkjunwoo.com/microstral/pkg/param/checksum.go
+package param
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 식별번호 검증 실패 사유. 검증 함수가 반환하는 오류는 %w로 이 값들을 감쌉니다.
+var (
+	ErrFormat     = errors.New("invalid format")             // 자릿수, 구분자 등 형식 오류
+	ErrChecksum   = errors.New("checksum mismatch")          // 검증번호(체크 디지트) 불일치
+	ErrBirthDate  = errors.New("invalid birth date")         // 존재하지 않거나 미래인 생년월일
+	ErrCardLength = errors.New("invalid card number length") // 카드사별 자릿수 불일치
+	ErrCardBrand  = errors.New("unknown card brand")         // 지원하지 않는 카드 번호 대역
+)
+
+// 카드 브랜드
+const (
+	CardVisa       = "visa"
+	CardMastercard = "mastercard"
+	CardAmex       = "amex"
+	CardJCB        = "jcb"
+	CardBC         = "bc"
+	CardUnionPay   = "unionpay"
+	CardDiscover   = "discover"
+	CardDiners     = "diners"
+)
+
+// 주민/외국인등록번호 검증번호 가중치 (앞 12자리)
+var registrationWeights = [12]int{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5}
+
+// 사업자등록번호 검증번호 가중치 (앞 9자리)
+var businessWeights = [9]int{1, 3, 7, 1, 3, 7, 1, 3, 5}
+
+// registrationChecksumUntil 이후 출생자는 검증번호를 검사하지 않습니다.
+// 2020년 10월 5일 주민등록번호 부여 체계 개편으로, 이후 부여하는 번호는 성별 자리 뒤 6자리가
+// 지역번호와 검증번호 없는 임의 번호라 마지막 자리를 계산으로 확인할 수 없습니다.
+// 번호에는 부여일이 없으므로 생년월일로 판단하며, 출생신고 기한(1개월) 안에 개편 후 번호를 받았을 수 있는
+// 2020년 9월 5일 출생자부터 제외합니다. 그 이전 출생자라도 개편 후 번호를 새로 받았다면(번호 변경 등)
+// 검증번호가 맞지 않아 ErrChecksum을 반환할 수 있습니다.
+var registrationChecksumUntil = time.Date(2020, time.September, 5, 0, 0, 0, 0, time.UTC)
+
+// validRegistrationNumber는 13자리 주민/외국인등록번호의 생년월일과 검증번호를 검사합니다.
+// offset은 검증번호 계산식 (offset - 합 % 11) % 10의 상수로, 내국인 11, 외국인 13입니다.
+func validRegistrationNumber(digits string, offset int) (bool, error) {
+	birth, err := registrationBirthDate(digits)
+	if err != nil {
+		return false, err
+	}
+	if !birth.Before(registrationChecksumUntil) {
+		return true, nil
+	}
+	sum := 0
+	for i, w := range registrationWeights {
+		sum += int(digits[i]-'0') * w
+	}
+	if check := (offset - sum%11) % 10; check != int(digits[12]-'0') {
+		return false, fmt.Errorf("%w: registration number check digit", ErrChecksum)
+	}
+	return true, nil
+}
+
+// registrationBirthDate는 앞 6자리와 성별 자리로 생년월일을 구합니다.
+//   - 1, 2, 5, 6: 1900년대 / 3, 4, 7, 8: 2000년대 / 9, 0: 1800년대
+func registrationBirthDate(digits string) (time.Time, error) {
+	century := 0
+	switch digits[6] {
+	case '1', '2', '5', '6':
+		century = 1900
+	case '3', '4', '7', '8':
+		century = 2000
+	case '9', '0':
+		century = 1800
+	default:
+		return time.Time{}, fmt.Errorf("%w: unknown gender digit %c", ErrFormat, digits[6])
+	}
+	yy, _ := strconv.Atoi(digits[0:2])
+	mm, _ := strconv.Atoi(digits[2:4])
+	dd, _ := strconv.Atoi(digits[4:6])
+	birth := time.Date(century+yy, time.Month(mm), dd, 0, 0, 0, 0, time.UTC)
+	// time.Date는 2월 30일 등을 다음 달로 넘기므로 되돌려 비교
+	if birth.Year() != century+yy || int(birth.Month()) != mm || birth.Day() != dd {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrBirthDate, digits[0:6])
+	}
+	if birth.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("%w: %s is in the future", ErrBirthDate, birth.Format("2006-01-02"))
+	}
+	return birth, nil
+}
+
+// validBusinessNumber는 10자리 사업자등록번호의 검증번호를 검사합니다.
+// 앞 9자리에 가중치를 곱해 더하고, 9번째 자리 × 5의 십의 자리를 더한 합으로 (10 - 합 % 10) % 10을 구합니다.
+func validBusinessNumber(digits string) (bool, error) {
+	sum := 0
+	for i, w := range businessWeights {
+		sum += int(digits[i]-'0') * w
+	}
+	sum += int(digits[8]-'0') * 5 / 10
+	if check := (10 - sum%10) % 10; check != int(digits[9]-'0') {
+		return false, fmt.Errorf("%w: business registration number check digit", ErrChecksum)
+	}
+	return true, nil
+}
+
+// Luhn은 숫자 문자열이 Luhn(mod 10) 검증을 통과하는지 반환합니다.
+func Luhn(digits string) bool {
+	if digits == "" {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// cardBrand는 카드 브랜드의 번호 대역(IIN)과 허용 자릿수입니다.
+type cardBrand struct {
+	name    string
+	ranges  [][2]int // 앞자리 범위 (자릿수가 같은 시작, 끝)
+	lengths []int
+}
+
+// 앞쪽 항목이 우선 (UnionPay 62 대역은 Discover 제휴 대역보다 먼저 검사)
+var cardBrands = []cardBrand{
+	{CardAmex, [][2]int{{34, 34}, {37, 37}}, []int{15}},
+	{CardDiners, [][2]int{{300, 305}, {36, 36}, {38, 39}}, []int{14, 15, 16, 17, 18, 19}},
+	{CardJCB, [][2]int{{3528, 3589}}, []int{16, 17, 18, 19}},
+	{CardVisa, [][2]int{{4, 4}}, []int{13, 16, 19}},
+	{CardMastercard, [][2]int{{51, 55}, {2221, 2720}}, []int{16}},
+	{CardUnionPay, [][2]int{{62, 62}, {81, 81}}, []int{16, 17, 18, 19}},
+	{CardDiscover, [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, []int{16, 17, 18, 19}},
+	// BC카드 국내 전용 대역
+	{CardBC, [][2]int{{94, 94}}, []int{16}},
+}
+
+// CardBrand는 카드 번호(숫자만)의 브랜드를 반환합니다.
+// 알 수 없는 대역이면 ErrCardBrand, 브랜드의 자릿수와 맞지 않으면 ErrCardLength를 감싼 오류를 반환합니다.
+func CardBrand(digits string) (string, error) {
+	for _, b := range cardBrands {
+		if !b.matches(digits) {
+			continue
+		}
+		for _, n := range b.lengths {
+			if len(digits) == n {
+				return b.name, nil
+			}
+		}
+		return b.name, fmt.Errorf("%w: %s card must be %s digits", ErrCardLength, b.name, joinInts(b.lengths))
+	}
+	return "", ErrCardBrand
+}
+
+func (b cardBrand) matches(digits string) bool {
+	for _, r := range b.ranges {
+		width := len(strconv.Itoa(r[0]))
+		if len(digits) < width {
+			continue
+		}
+		prefix, err := strconv.Atoi(digits[:width])
+		if err == nil && prefix >= r[0] && prefix <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,188 @@
+// parkjunwoo.com/microstral/pkg/param/checksum_test.go
+package param
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// checkResult는 검증 함수의 결과가 기대한 오류(nil이면 성공)와 맞는지 확인합니다.
+func checkResult(t *testing.T, value string, ok bool, err error, want error) {
+	t.Helper()
+	if want == nil {
+		if !ok || err != nil {
+			t.Errorf("%q: got (%v, %v), want valid", value, ok, err)
+		}
+		return
+	}
+	if ok || !errors.Is(err, want) {
+		t.Errorf("%q: got (%v, %v), want %v", value, ok, err, want)
+	}
+}
+
+func TestValidSSNKR(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"900101-1234568", nil},
+		{"9001011234568", nil},
+		{"850315-2345678", nil},
+		{"000229-3123454", nil}, // 2000년 윤일
+		{"900101-1234567", ErrChecksum},
+		{"850315-2345670", ErrChecksum},
+		{"900230-1234568", ErrBirthDate}, // 2월 30일
+		{"010229-3123454", ErrBirthDate}, // 2001년은 윤년이 아님
+		{"901301-1234568", ErrBirthDate}, // 13월
+		{"991231-3123456", ErrBirthDate}, // 2099년 (미래)
+		{"900101-5234568", ErrFormat},    // 외국인 성별 자리
+		{"900101-123456", ErrFormat},
+		{"90010a-1234568", ErrFormat},
+	}
+	for _, tt := range tests {
+		ok, err := ValidSSNKR(tt.value)
+		checkResult(t, tt.value, ok, err, tt.want)
+	}
+}
+
+func TestValidSSNKRChecksumCutoff(t *testing.T) {
+	// 번호 부여 체계 개편 후 번호를 받았을 수 있는 출생자는 검증번호를 검사하지 않음
+	if want := time.Date(2020, time.September, 5, 0, 0, 0, 0, time.UTC); !registrationChecksumUntil.Equal(want) {
+		t.Fatalf("registrationChecksumUntil = %v, want %v", registrationChecksumUntil, want)
+	}
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"200904-3123451", nil},         // 기준일 전날, 검증번호 일치
+		{"200904-3123450", ErrChecksum}, // 기준일 전날, 검증번호 불일치
+		{"200905-3123455", nil},         // 기준일, 검증번호 일치
+		{"200905-3123450", nil},         // 기준일, 검증번호를 검사하지 않음
+		{"201015-4987654", nil},         // 개편 후 출생, 임의 번호
+		{"201031-4000000", nil},
+		{"201032-4000000", ErrBirthDate}, // 검증번호는 건너뛰어도 생년월일은 검사
+	}
+	for _, tt := range tests {
+		ok, err := ValidSSNKR(tt.value)
+		checkResult(t, tt.value, ok, err, tt.want)
+	}
+}
+
+func TestValidRRNKR(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"900101-5123452", nil},
+		{"990101-6234569", nil},
+		{"200904-7123454", nil},
+		{"900101-5123451", ErrChecksum},
+		{"900101-1234568", ErrFormat}, // 내국인 성별 자리
+		{"900132-5123452", ErrBirthDate},
+	}
+	for _, tt := range tests {
+		ok, err := ValidRRNKR(tt.value)
+		checkResult(t, tt.value, ok, err, tt.want)
+	}
+}
+
+func TestValidBRNKR(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"220-81-62517", nil},
+		{"2208162517", nil},
+		{"123-45-67891", nil},
+		{"101-81-01112", nil},
+		{"220-81-62518", ErrChecksum},
+		{"123-45-67890", ErrChecksum},
+		{"220-81-6251", ErrFormat},
+		{"220-81-6251a", ErrFormat},
+	}
+	for _, tt := range tests {
+		ok, err := ValidBRNKR(tt.value)
+		checkResult(t, tt.value, ok, err, tt.want)
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"4111111111111111", true},
+		{"79927398713", true},
+		{"0", true},
+		{"4111111111111112", false},
+		{"79927398710", false},
+		{"", false},
+		{"4111-1111", false},
+	}
+	for _, tt := range tests {
+		if got := Luhn(tt.value); got != tt.want {
+			t.Errorf("Luhn(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	tests := []struct {
+		value string
+		brand string
+		want  error
+	}{
+		{"4111111111111111", CardVisa, nil},
+		{"4111111111119", CardVisa, nil},
+		{"5555555555554444", CardMastercard, nil},
+		{"2223003122003222", CardMastercard, nil},
+		{"378282246310005", CardAmex, nil},
+		{"371449635398431", CardAmex, nil},
+		{"3530111333300000", CardJCB, nil},
+		{"30569309025904", CardDiners, nil},
+		{"6011111111111117", CardDiscover, nil},
+		{"6200000000000005", CardUnionPay, nil},
+		{"8123456789012340", CardUnionPay, nil},
+		{"9412345678901234", CardBC, nil},
+		{"41111111111111", CardVisa, ErrCardLength},
+		{"3782822463100", CardAmex, ErrCardLength},
+		{"55555555555544443", CardMastercard, ErrCardLength},
+		{"2721000000000000", "", ErrCardBrand}, // Mastercard 2-series 대역 밖
+		{"9912345678901234", "", ErrCardBrand},
+		{"1234567890123456", "", ErrCardBrand},
+	}
+	for _, tt := range tests {
+		brand, err := CardBrand(tt.value)
+		if brand != tt.brand {
+			t.Errorf("CardBrand(%q) brand = %q, want %q", tt.value, brand, tt.brand)
+		}
+		if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("CardBrand(%q) error = %v, want %v", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestValidCreditcard(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"4111 1111 1111 1111", nil},
+		{"5555-5555-5555-4444", nil},
+		{"3782 822463 10005", nil},
+		{"9412345678901234", nil},
+		{"4111111111111112", ErrChecksum},
+		{"378282246310006", ErrChecksum},
+		{"411111111111", ErrCardLength},         // 12자리
+		{"41111111111111111111", ErrCardLength}, // 20자리
+		{"41111111111111", ErrCardLength},       // Visa 14자리
+		{"1234567890123452", ErrCardBrand},
+		{"4111-1111-1111-111a", ErrFormat},
+		{"4111--1111", ErrFormat},
+	}
+	for _, tt := range tests {
+		ok, err := ValidCreditcard(tt.value)
+		checkResult(t, tt.value, ok, err, tt.want)
+	}
+}
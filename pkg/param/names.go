@@ -0,0 +1,133 @@
+// parkjunwoo.com/microstral/pkg/param/names.go
+package param
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	typeNamesMu sync.RWMutex
+	// 태그(param:"type=EMAIL")에서 사용하는 타입 이름
+	typeNames = map[string]uint32{
+		"FLAG":  FLAG,
+		"REGEX": REGEX,
+
+		"PHONE_NANP": PHONE_NANP,
+		"PHONE_RU":   PHONE_RU,
+		"PHONE_FR":   PHONE_FR,
+		"PHONE_ES":   PHONE_ES,
+		"PHONE_IT":   PHONE_IT,
+		"PHONE_GB":   PHONE_GB,
+		"PHONE_DE":   PHONE_DE,
+		"PHONE_BR":   PHONE_BR,
+		"PHONE_MY":   PHONE_MY,
+		"PHONE_AU":   PHONE_AU,
+		"PHONE_ID":   PHONE_ID,
+		"PHONE_PH":   PHONE_PH,
+		"PHONE_TH":   PHONE_TH,
+		"PHONE_JP":   PHONE_JP,
+		"PHONE_KR":   PHONE_KR,
+		"PHONE_VN":   PHONE_VN,
+		"PHONE_CN":   PHONE_CN,
+		"PHONE_TR":   PHONE_TR,
+		"PHONE_IN":   PHONE_IN,
+		"PHONE_PK":   PHONE_PK,
+		"PHONE_IR":   PHONE_IR,
+		"PHONE_BD":   PHONE_BD,
+		"PHONE_JO":   PHONE_JO,
+		"PHONE_KW":   PHONE_KW,
+		"PHONE_SA":   PHONE_SA,
+		"PHONE_AE":   PHONE_AE,
+		"PHONE_IL":   PHONE_IL,
+		"PHONE_AZ":   PHONE_AZ,
+		"PHONE_UZ":   PHONE_UZ,
+		"MOBILE_KR":  MOBILE_KR,
+		"PHONE_US":   PHONE_US,
+		"PHONE_CA":   PHONE_CA,
+		"PHONE":      PHONE,
+		"PHONE_E164": PHONE_E164,
+
+		"DATE":      DATE,
+		"TIME":      TIME,
+		"DATE_TIME": DATE_TIME,
+		"UNIX_TIME": UNIX_TIME,
+		"UTC_TIME":  UTC_TIME,
+		"DURATION":  DURATION,
+
+		"HTML":     HTML,
+		"JSON":     JSON,
+		"XML":      XML,
+		"YAML":     YAML,
+		"CSV":      CSV,
+		"BASE64":   BASE64,
+		"JWT":      JWT,
+		"MARKDOWN": MARKDOWN,
+
+		"URL":      URL,
+		"DOMAIN":   DOMAIN,
+		"PATH":     PATH,
+		"QUERY":    QUERY,
+		"FRAGMENT": FRAGMENT,
+		"SLUG":     SLUG,
+		"FILE":     FILE,
+		"MIME":     MIME,
+		"IP":       IP,
+		"IPV4":     IPV4,
+		"IPV6":     IPV6,
+		"MAC":      MAC,
+		"UUID":     UUID,
+
+		"COLOR": COLOR,
+		"RGB":   RGB,
+		"RGBA":  RGBA,
+		"HSL":   HSL,
+		"HSLA":  HSLA,
+
+		"ID": ID,
+
+		"PASSWORD":        PASSWORD,
+		"PASSWORD_STRONG": PASSWORD_STRONG,
+		"EMAIL":           EMAIL,
+		"CREDITCARD":      CREDITCARD,
+
+		"NAME_KR":            NAME_KR,
+		"TITLE_KR":           TITLE_KR,
+		"SSN_KR":             SSN_KR,
+		"RRN_KR":             RRN_KR,
+		"BRN_KR":             BRN_KR,
+		"PCC_KR":             PCC_KR,
+		"PASSPORT_KR":        PASSPORT_KR,
+		"DRIVING_LICENSE_KR": DRIVING_LICENSE_KR,
+		"ZIPCODE_KR":         ZIPCODE_KR,
+	}
+)
+
+// RegisterType은 이름이 있는 사용자 정의 타입과 검증 함수를 등록합니다.
+// 등록한 이름은 param:"type=NAME" 태그에서 사용할 수 있습니다.
+func RegisterType(name string, typ uint32, fn ValidFunc) {
+	typeNamesMu.Lock()
+	typeNames[strings.ToUpper(name)] = typ
+	typeNamesMu.Unlock()
+	RegisterValidFunc(typ, fn)
+}
+
+// TypeByName은 타입 이름(대소문자 무시)에 해당하는 타입 값을 반환합니다.
+func TypeByName(name string) (uint32, bool) {
+	typeNamesMu.RLock()
+	defer typeNamesMu.RUnlock()
+	typ, ok := typeNames[strings.ToUpper(name)]
+	return typ, ok
+}
+
+// TypeName은 타입 값의 이름을 반환합니다. 이름이 없으면 빈 문자열을 반환합니다.
+func TypeName(typ uint32) string {
+	typeNamesMu.RLock()
+	defer typeNamesMu.RUnlock()
+	for name, t := range typeNames {
+		if t == typ {
+			return name
+		}
+	}
+	return ""
+}
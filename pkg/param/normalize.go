@@ -0,0 +1,54 @@
+// parkjunwoo.com/microstral/pkg/param/normalize.go
+package param
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NormalizeFunc는 검증을 통과한 값을 저장용 정규 형식으로 바꿉니다.
+type NormalizeFunc func(value string) (string, error)
+
+var (
+	normalizeFuncsMu sync.RWMutex
+	normalizeFuncs   = make(map[uint32]NormalizeFunc)
+)
+
+// RegisterNormalizeFunc는 타입의 정규화 함수를 등록합니다. 같은 타입에 다시 등록하면 교체합니다.
+func RegisterNormalizeFunc(typ uint32, fn NormalizeFunc) {
+	normalizeFuncsMu.Lock()
+	normalizeFuncs[typ] = fn
+	normalizeFuncsMu.Unlock()
+}
+
+// lookupNormalizeFunc는 타입에 등록된 정규화 함수를 반환합니다.
+func lookupNormalizeFunc(typ uint32) (NormalizeFunc, bool) {
+	normalizeFuncsMu.RLock()
+	defer normalizeFuncsMu.RUnlock()
+	fn, ok := normalizeFuncs[typ]
+	return fn, ok
+}
+
+// Parse는 Default를 적용하고 검증한 뒤, 타입에 정규화 함수가 있으면 정규 형식으로 바꾼 값을 반환합니다.
+// 예: MOBILE_KR "010-1234-5678" → "+821012345678", EMAIL "User@Example.COM" → "user@example.com"
+func (p *Param) Parse(input string) (string, error) {
+	value, err := p.Value(input)
+	if err != nil || value == "" {
+		return value, err
+	}
+	if v, ok := p.enumValue(value); ok {
+		value = v
+	}
+	if p.Type == FLAG || p.Type == REGEX {
+		return value, nil
+	}
+	fn, ok := lookupNormalizeFunc(p.Type)
+	if !ok {
+		return value, nil
+	}
+	normalized, err := fn(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", p.Name, err)
+	}
+	return normalized, nil
+}
@@ -2,40 +2,114 @@
 package param
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"parkjunwoo.com/microstral/pkg/flag"
 )
 
+// 제약 조건 위반 오류. Validate가 반환하는 오류는 %w로 이 값들을 감쌉니다.
+var (
+	ErrRequired   = errors.New("value is required")
+	ErrTooShort   = errors.New("value is too short")
+	ErrTooLong    = errors.New("value is too long")
+	ErrOutOfRange = errors.New("value is out of range")
+	ErrStep       = errors.New("value does not match step")
+	ErrNotAllowed = errors.New("value is not allowed")
+)
+
+// Param은 파라미터 하나의 형식과 제약 조건입니다. 0 값인 제약 조건은 검사하지 않습니다.
+//
+//	Param{Name: "limit", Default: "60", Type: FLAG, Flag: flag.UINT, Min: Float(1), Max: Float(100)}
 type Param struct {
 	Name     string
-	Default  string
+	Default  string // 입력이 비어 있을 때 대신 사용할 값
 	Type     uint32
 	Flag     uint64
 	Required bool
 	Regex    *regexp.Regexp
+
+	MinLength int // 최소 글자 수 (rune)
+	MaxLength int // 최대 글자 수 (rune)
+	MinBytes  int // 최소 바이트 수
+	MaxBytes  int // 최대 바이트 수
+
+	// 숫자 범위와 간격: Type이 FLAG이고 Flag가 숫자 플래그(UINT, INT, UNUM, NUM, OCT, HEX)만일 때 검사
+	Min  *float64
+	Max  *float64
+	Step float64 // Min(없으면 0)부터의 간격
+
+	Enum     []string // 허용 값 목록
+	EnumFold bool     // Enum을 대소문자 구분 없이 비교 (Parse는 Enum에 적힌 값으로 바꿈)
+}
+
+// enumValue는 input과 일치하는 Enum 값을 반환합니다. EnumFold이면 대소문자를 구분하지 않습니다.
+func (p *Param) enumValue(input string) (string, bool) {
+	for _, v := range p.Enum {
+		if v == input || p.EnumFold && strings.EqualFold(v, input) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Float은 Param.Min, Param.Max에 쓸 포인터를 반환합니다.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Value는 입력이 비어 있으면 Default를 적용한 뒤 검증하고, 사용할 값을 반환합니다.
+func (p *Param) Value(input string) (string, error) {
+	if input == "" {
+		input = p.Default
+	}
+	valid, err := p.Validate(input)
+	if err != nil {
+		return "", err
+	}
+	if !valid {
+		return "", fmt.Errorf("invalid %s: %q", p.Name, input)
+	}
+	return input, nil
 }
 
 func (p *Param) Validate(input string) (bool, error) {
-	// Required=false 이면서 input이 비어 있다면 통과
-	if input == "" && !p.Required {
-		return true, nil
+	// 비어 있으면 Default를 검증, Default도 없으면 Required=false일 때만 통과
+	if input == "" {
+		if p.Default == "" {
+			if p.Required {
+				return false, ErrRequired
+			}
+			return true, nil
+		}
+		input = p.Default
+	}
+
+	// 글자 수, 바이트 수, 허용 값 검사
+	if err := p.checkLength(input); err != nil {
+		return false, err
+	}
+	if _, ok := p.enumValue(input); len(p.Enum) > 0 && !ok {
+		return false, fmt.Errorf("%w: must be one of %s", ErrNotAllowed, strings.Join(p.Enum, ", "))
 	}
 
 	// v.Type으로 먼저 분기
 	switch p.Type {
-	// FLAG 기반 검증
+	// FLAG 기반 검증: 모든 문자가 p.Flag에 활성화된 문자 집합에 속해야 함
 	case FLAG:
-		// 숫자 허용 여부
-		if (p.Flag & flag.NUM) != 0 {
-
+		if err := flag.Validate(input, p.Flag); err != nil {
+			return false, err
 		}
-
-		if (p.Flag & flag.HANGUL) != 0 {
-
+		// 숫자 플래그만 있으면 범위와 간격 검사
+		if flag.IsNumeric(p.Flag) {
+			if err := p.checkRange(input); err != nil {
+				return false, err
+			}
 		}
-
 		return true, nil
 	// 사용자 정의 패턴의 정규식 검증
 	case REGEX:
@@ -54,7 +128,7 @@ func (p *Param) Validate(input string) (bool, error) {
 		return true, nil
 	default:
 		// Type에 따른 검증 함수 탐색
-		fn, ok := validFuncs[p.Type]
+		fn, ok := lookupValidFunc(p.Type)
 		if !ok {
 			return false, fmt.Errorf("undefined parameter type %d", p.Type)
 		}
@@ -68,5 +142,51 @@ func (p *Param) Validate(input string) (bool, error) {
 		// 결과 반환
 		return result, nil
 	}
+}
+
+func (p *Param) checkLength(input string) error {
+	if p.MinLength > 0 || p.MaxLength > 0 {
+		length := utf8.RuneCountInString(input)
+		if p.MinLength > 0 && length < p.MinLength {
+			return fmt.Errorf("%w: must be at least %d characters", ErrTooShort, p.MinLength)
+		}
+		if p.MaxLength > 0 && length > p.MaxLength {
+			return fmt.Errorf("%w: must be at most %d characters", ErrTooLong, p.MaxLength)
+		}
+	}
+	if p.MinBytes > 0 && len(input) < p.MinBytes {
+		return fmt.Errorf("%w: must be at least %d bytes", ErrTooShort, p.MinBytes)
+	}
+	if p.MaxBytes > 0 && len(input) > p.MaxBytes {
+		return fmt.Errorf("%w: must be at most %d bytes", ErrTooLong, p.MaxBytes)
+	}
+	return nil
+}
 
+func (p *Param) checkRange(input string) error {
+	if p.Min == nil && p.Max == nil && p.Step == 0 {
+		return nil
+	}
+	n, err := flag.ParseNumber(input, p.Flag)
+	if err != nil {
+		return err
+	}
+	if p.Min != nil && n < *p.Min {
+		return fmt.Errorf("%w: must be at least %g", ErrOutOfRange, *p.Min)
+	}
+	if p.Max != nil && n > *p.Max {
+		return fmt.Errorf("%w: must be at most %g", ErrOutOfRange, *p.Max)
+	}
+	if p.Step > 0 {
+		base := 0.0
+		if p.Min != nil {
+			base = *p.Min
+		}
+		// 실수 오차를 고려해 가장 가까운 배수와 비교
+		q := (n - base) / p.Step
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			return fmt.Errorf("%w: must be a multiple of %g", ErrStep, p.Step)
+		}
+	}
+	return nil
 }
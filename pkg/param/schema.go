@@ -0,0 +1,252 @@
+// parkjunwoo.com/microstral/pkg/param/schema.go
+package param
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/flag"
+)
+
+// Schema는 요청 하나의 파라미터 묶음입니다. 모든 파라미터를 한 번에 검증하고 기본값을 적용합니다.
+//
+//	var listSchema = param.NewSchema("list",
+//		param.Param{Name: "limit", Default: "60", Type: param.FLAG, Flag: flag.UINT, Min: param.Float(1), Max: param.Float(100)},
+//		param.Param{Name: "order", Default: "created_at", Enum: []string{"created_at", "name"}},
+//	)
+//
+//	values, err := listSchema.Bind(c)
+//	limit := values.Int("limit")
+type Schema struct {
+	Name   string
+	Params []Param
+}
+
+// NewSchema는 이름과 파라미터 목록으로 Schema를 생성합니다.
+// 파라미터 이름이 비어 있거나 중복되면 panic합니다. (정의 오류)
+func NewSchema(name string, params ...Param) *Schema {
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		if p.Name == "" {
+			panic(fmt.Sprintf("param: schema %s has a parameter without name", name))
+		}
+		if seen[p.Name] {
+			panic(fmt.Sprintf("param: schema %s has duplicate parameter %s", name, p.Name))
+		}
+		seen[p.Name] = true
+	}
+	return &Schema{Name: name, Params: params}
+}
+
+// Validate는 이름 → 값 맵을 검증합니다.
+func (s *Schema) Validate(input map[string]string) (Values, error) {
+	values := make(url.Values, len(input))
+	for name, v := range input {
+		values.Set(name, v)
+	}
+	return s.ValidateValues(values)
+}
+
+// ValidateValues는 쿼리나 폼 값을 검증합니다. 값이 여러 개인 파라미터는 모든 값을 검사합니다.
+// 첫 번째 실패가 아니라 모든 필드 오류를 담은 ValidationErrors를 반환하며,
+// 성공하면 기본값을 적용하고 정규화(Param.Parse)한 Values를 반환합니다. 스키마에 없는 이름은 무시합니다.
+func (s *Schema) ValidateValues(input url.Values) (Values, error) {
+	result := Values{schema: s, values: make(url.Values, len(s.Params))}
+	var errs ValidationErrors
+	for i := range s.Params {
+		p := &s.Params[i]
+		inputs := input[p.Name]
+		if len(inputs) == 0 {
+			inputs = []string{""}
+		}
+		checked := make([]string, 0, len(inputs))
+		for _, in := range inputs {
+			v, err := p.Parse(in)
+			if err != nil {
+				errs = append(errs, fieldError(p.Name, err))
+				break
+			}
+			checked = append(checked, v)
+		}
+		if len(checked) == len(inputs) && !(len(checked) == 1 && checked[0] == "") {
+			result.values[p.Name] = checked
+		}
+	}
+	if len(errs) > 0 {
+		return Values{}, errs
+	}
+	return result, nil
+}
+
+// Bind는 gin 요청의 쿼리, 본문(JSON 객체 또는 폼), 경로 파라미터를 모아 검증합니다.
+// 같은 이름이 여러 곳에 있으면 경로, 본문, 쿼리 순서로 우선합니다.
+// 본문을 해석하지 못하면 *BindError를 반환합니다.
+func (s *Schema) Bind(c *gin.Context) (Values, error) {
+	values := url.Values{}
+	for name, v := range c.Request.URL.Query() {
+		values[name] = v
+	}
+	body, err := bodyValues(c.Request)
+	if err != nil {
+		return Values{}, &BindError{Err: err}
+	}
+	for name, v := range body {
+		values[name] = v
+	}
+	for _, p := range c.Params {
+		values.Set(p.Key, p.Value)
+	}
+	return s.ValidateValues(values)
+}
+
+// bodyValues는 요청 본문을 이름 → 값 목록으로 읽습니다. JSON은 최상위 객체의 스칼라와 스칼라 배열만 사용합니다.
+func bodyValues(r *http.Request) (url.Values, error) {
+	if r.Body == nil || r.Body == http.NoBody || r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return nil, nil
+	}
+
+	switch contentType := filterFlags(r.Header.Get("Content-Type")); {
+	case contentType == gin.MIMEJSON || strings.HasSuffix(contentType, "+json"):
+		var object map[string]any
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		values := make(url.Values, len(object))
+		for name, v := range object {
+			if list, ok := v.([]any); ok {
+				for _, item := range list {
+					if s, ok := jsonScalar(item); ok {
+						values.Add(name, s)
+					}
+				}
+				continue
+			}
+			if s, ok := jsonScalar(v); ok {
+				values.Set(name, s)
+			}
+		}
+		return values, nil
+	case contentType == gin.MIMEMultipartPOSTForm:
+		if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return nil, err
+		}
+		return r.PostForm, nil
+	case contentType == gin.MIMEPOSTForm:
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return r.PostForm, nil
+	default:
+		return nil, nil
+	}
+}
+
+func jsonScalar(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		// null, 객체는 값이 없는 것으로 봄
+		return "", false
+	}
+}
+
+// Values는 Schema로 검증한 값입니다. 값이 없고 기본값도 없는 파라미터는 빈 값으로 조회됩니다.
+type Values struct {
+	schema *Schema
+	values url.Values
+}
+
+// Has는 파라미터에 값(기본값 포함)이 있는지 반환합니다.
+func (v Values) Has(name string) bool {
+	return len(v.values[name]) > 0
+}
+
+// String은 파라미터의 첫 번째 값을 반환합니다.
+func (v Values) String(name string) string {
+	return v.values.Get(name)
+}
+
+// Strings는 파라미터의 모든 값을 반환합니다.
+func (v Values) Strings(name string) []string {
+	return v.values[name]
+}
+
+// Int는 파라미터 값을 int로 반환합니다. 값이 없거나 정수가 아니면 0입니다.
+func (v Values) Int(name string) int {
+	return int(v.Int64(name))
+}
+
+// Int64는 파라미터 값을 int64로 반환합니다. OCT, HEX 플래그 파라미터는 해당 진법으로 해석합니다.
+func (v Values) Int64(name string) int64 {
+	s := v.String(name)
+	if s == "" {
+		return 0
+	}
+	if f, ok := v.radixFlag(name); ok {
+		n, _ := flag.ParseNumber(s, f)
+		return int64(n)
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+// Float은 파라미터 값을 float64로 반환합니다. 값이 없거나 숫자가 아니면 0입니다.
+func (v Values) Float(name string) float64 {
+	s := v.String(name)
+	if s == "" {
+		return 0
+	}
+	if f, ok := v.radixFlag(name); ok {
+		n, _ := flag.ParseNumber(s, f)
+		return n
+	}
+	n, _ := strconv.ParseFloat(s, 64)
+	return n
+}
+
+// Bool은 파라미터 값을 bool로 반환합니다. 값이 없거나 불리언이 아니면 false입니다.
+func (v Values) Bool(name string) bool {
+	b, _ := strconv.ParseBool(v.String(name))
+	return b
+}
+
+// Map은 파라미터별 첫 번째 값을 맵으로 반환합니다.
+func (v Values) Map() map[string]string {
+	m := make(map[string]string, len(v.values))
+	for name := range v.values {
+		m[name] = v.values.Get(name)
+	}
+	return m
+}
+
+// radixFlag는 파라미터가 10진수가 아닌 숫자 플래그(OCT, HEX)로만 정의되었으면 그 플래그를 반환합니다.
+func (v Values) radixFlag(name string) (uint64, bool) {
+	if v.schema == nil {
+		return 0, false
+	}
+	for _, p := range v.schema.Params {
+		if p.Name == name {
+			ok := p.Type == FLAG && flag.IsNumeric(p.Flag) && p.Flag&(flag.UINT|flag.UNUM) == 0 && p.Flag&(flag.OCT|flag.HEX) != 0
+			return p.Flag, ok
+		}
+	}
+	return 0, false
+}
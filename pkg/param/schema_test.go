@@ -0,0 +1,32 @@
+// parkjunwoo.com/microstral/pkg/param/schema_test.go
+package param
+
+import "testing"
+
+func TestSchemaEnumFold(t *testing.T) {
+	schema := NewSchema("list",
+		Param{Name: "desc", Default: "DESC", Enum: []string{"ASC", "DESC"}, EnumFold: true},
+		Param{Name: "order", Enum: []string{"created_at", "name"}},
+	)
+	tests := []struct {
+		input map[string]string
+		desc  string
+		ok    bool
+	}{
+		{map[string]string{}, "DESC", true},
+		{map[string]string{"desc": "asc"}, "ASC", true},
+		{map[string]string{"desc": "Desc"}, "DESC", true},
+		{map[string]string{"desc": "down"}, "", false},
+		{map[string]string{"order": "NAME"}, "", false}, // EnumFold가 없으면 대소문자 구분
+	}
+	for _, tt := range tests {
+		values, err := schema.Validate(tt.input)
+		if (err == nil) != tt.ok {
+			t.Errorf("Validate(%v) error = %v, want ok %v", tt.input, err, tt.ok)
+			continue
+		}
+		if tt.ok && values.String("desc") != tt.desc {
+			t.Errorf("Validate(%v) desc = %q, want %q", tt.input, values.String("desc"), tt.desc)
+		}
+	}
+}
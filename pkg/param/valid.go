@@ -1,11 +1,28 @@
 // parkjunwoo.com/microstral/pkg/param/valid.go
 package param
 
+import "sync"
+
 type ValidFunc func(value string) (bool, error)
 
-var validFuncs = make(map[uint32]ValidFunc)
+var (
+	validFuncsMu sync.RWMutex
+	validFuncs   = make(map[uint32]ValidFunc)
+)
 
 // 함수 포인터 대신 함수 그 자체를 인자로 받도록 수정
+// 이름이 있는 타입이면 RegisterValidator로 등록한 검증기에도 태그로 등록됩니다.
 func RegisterValidFunc(typ uint32, fn ValidFunc) {
+	validFuncsMu.Lock()
 	validFuncs[typ] = fn
+	validFuncsMu.Unlock()
+	registerValidatorTag(typ)
+}
+
+// lookupValidFunc는 타입에 등록된 검증 함수를 반환합니다.
+func lookupValidFunc(typ uint32) (ValidFunc, bool) {
+	validFuncsMu.RLock()
+	defer validFuncsMu.RUnlock()
+	fn, ok := validFuncs[typ]
+	return fn, ok
 }
@@ -4,6 +4,9 @@ package param
 import (
 	"fmt"
 	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 func init() {
@@ -16,6 +19,12 @@ func init() {
 	RegisterValidFunc(PASSPORT_KR, ValidPassportKR)
 	RegisterValidFunc(DRIVING_LICENSE_KR, ValidDLKR)
 	RegisterValidFunc(ZIPCODE_KR, ValidZipcodeKR)
+
+	RegisterNormalizeFunc(NAME_KR, NormalizeNameKR)
+	RegisterNormalizeFunc(SSN_KR, NormalizeDigits)
+	RegisterNormalizeFunc(RRN_KR, NormalizeDigits)
+	RegisterNormalizeFunc(BRN_KR, NormalizeDigits)
+	RegisterNormalizeFunc(PCC_KR, NormalizePCCKR)
 }
 
 var (
@@ -24,15 +33,16 @@ var (
 	regexSSNKR      = regexp.MustCompile(`^(\d{6})-?[1-4]{1}\d{6}$`)
 	regexRRNKR      = regexp.MustCompile(`^(\d{6})-?[5-8]{1}\d{6}$`)
 	regexBRNKR      = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{5})$`)
-	regexPCCKR      = regexp.MustCompile(`^P\d{12}$`)
+	regexPCCKR      = regexp.MustCompile(`^[Pp]\d{12}$`)
 	regexPassportKR = regexp.MustCompile(`^[A-Z]{1}[0-9]{8}$`)
 	regexDLKR       = regexp.MustCompile(`^\d{2}-?\d{2}-?\d{6}-?\d{2}$`)
 	regexZipcodeKR  = regexp.MustCompile(`^\d{5}$`)
 )
 
 // ValidNameKR은 한국 이름 형식이 맞는지 확인합니다.
+// 자모가 분리된(NFD) 한글도 NFC로 합친 뒤 검사합니다.
 func ValidNameKR(value string) (bool, error) {
-	return regNameKR.MatchString(value), nil
+	return regNameKR.MatchString(norm.NFC.String(value)), nil
 }
 
 // ValidTitleKR은 한국 제목 형식이 맞는지 확인합니다.
@@ -40,34 +50,44 @@ func ValidTitleKR(value string) (bool, error) {
 	return regTitleKR.MatchString(value), nil
 }
 
-// ValidSSN은 주민등록번호 형식이 맞는지 확인합니다.
+// ValidSSN은 주민등록번호의 형식, 생년월일, 검증번호가 맞는지 확인합니다.
+//   - 검증번호: 앞 12자리 × 가중치(2-9, 2-5) 합으로 (11 - 합 % 11) % 10
+//   - 번호 부여 체계 개편(2020-10-05) 이후 번호를 받았을 수 있는 출생자는 생년월일까지만 검사 (registrationChecksumUntil)
 func ValidSSNKR(value string) (bool, error) {
 	if !regexSSNKR.MatchString(value) {
-		return false, fmt.Errorf("invalid social security number format")
+		return false, fmt.Errorf("%w: social security number must be YYMMDD-GNNNNNN", ErrFormat)
 	}
-	return true, nil
+	return validRegistrationNumber(strings.ReplaceAll(value, "-", ""), 11)
 }
 
-// ValidRRN은 외국인등록번호 형식이 맞는지 확인합니다.
+// ValidRRN은 외국인등록번호의 형식, 생년월일, 검증번호가 맞는지 확인합니다.
+//   - 검증번호: 앞 12자리 × 가중치(2-9, 2-5) 합으로 (13 - 합 % 11) % 10
 func ValidRRNKR(value string) (bool, error) {
 	if !regexRRNKR.MatchString(value) {
-		return false, fmt.Errorf("invalid resident registration number format")
+		return false, fmt.Errorf("%w: resident registration number must be YYMMDD-GNNNNNN", ErrFormat)
 	}
-	return true, nil
+	return validRegistrationNumber(strings.ReplaceAll(value, "-", ""), 13)
 }
 
-// ValidBN은 사업자등록번호 형식이 맞는지 확인합니다.
+// ValidBN은 사업자등록번호의 형식과 검증번호가 맞는지 확인합니다.
 func ValidBRNKR(value string) (bool, error) {
 	if !regexBRNKR.MatchString(value) {
-		return false, fmt.Errorf("invalid business registration number format")
+		return false, fmt.Errorf("%w: business registration number must be NNN-NN-NNNNN", ErrFormat)
 	}
-	return true, nil
+	return validBusinessNumber(strings.ReplaceAll(value, "-", ""))
 }
 
 // ValidPCC은 개인통관고유부호 형식이 맞는지 확인합니다.
+//   - P(소문자 허용)로 시작하고 숫자 12자리, 하이픈/공백 없음
+//   - 발급 규칙이 공개되지 않아 검증번호는 검사하지 않음 (실제 유효성은 관세청 조회 필요)
 func ValidPCCKR(value string) (bool, error) {
-	if !regexPCCKR.MatchString(value) {
-		return false, fmt.Errorf("invalid personal customs code format")
+	switch {
+	case value == "" || (value[0] != 'P' && value[0] != 'p'):
+		return false, fmt.Errorf("%w: personal customs code must start with P", ErrFormat)
+	case len(value) != 13:
+		return false, fmt.Errorf("%w: personal customs code must be P followed by 12 digits", ErrFormat)
+	case !regexPCCKR.MatchString(value):
+		return false, fmt.Errorf("%w: personal customs code must contain only digits after P", ErrFormat)
 	}
 	return true, nil
 }
@@ -98,3 +118,18 @@ func ValidZipcodeKR(value string) (bool, error) {
 	}
 	return true, nil
 }
+
+// NormalizeNameKR은 이름을 NFC로 정규화하고 앞뒤 공백을 제거하며 연속된 공백을 하나로 합칩니다.
+func NormalizeNameKR(value string) (string, error) {
+	return strings.Join(strings.Fields(norm.NFC.String(value)), " "), nil
+}
+
+// NormalizeDigits는 주민등록번호, 사업자등록번호 등에서 하이픈을 제거합니다.
+func NormalizeDigits(value string) (string, error) {
+	return strings.ReplaceAll(value, "-", ""), nil
+}
+
+// NormalizePCCKR은 개인통관고유부호의 P를 대문자로 바꿉니다.
+func NormalizePCCKR(value string) (string, error) {
+	return strings.ToUpper(value), nil
+}
@@ -7,6 +7,10 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
 )
 
 func init() {
@@ -23,6 +27,9 @@ func init() {
 	RegisterValidFunc(IPV6, ValidIPv6)
 	RegisterValidFunc(MAC, ValidMAC)
 	RegisterValidFunc(UUID, ValidUUID)
+
+	RegisterNormalizeFunc(DOMAIN, NormalizeDomain)
+	RegisterNormalizeFunc(UUID, NormalizeUUID)
 }
 
 var (
@@ -32,7 +39,6 @@ var (
 	fragmentRegex = regexp.MustCompile(`^[A-Za-z0-9\-\._~!$&'()*+,;=:@/?]*$`)
 	slugRegex     = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
 	fileRegex     = regexp.MustCompile(`^[^\\/:*?"<>|\r\n]+$`)
-	uuidRegex     = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 )
 
 // ValidURL은 문자열이 유효한 URL인지 확인합니다.
@@ -52,10 +58,18 @@ func ValidURL(value string) (bool, error) {
 // - 전체 길이 255자 이하
 // - 각 라벨은 알파벳/숫자로 시작/끝나며, 중간에 '-' 허용
 // - 라벨 사이를 '.'으로 구분
+// - 국제화 도메인(한글.kr 등)은 퓨니코드(xn--)로 변환한 뒤 검사
 func ValidDomain(value string) (bool, error) {
 	if len(value) == 0 {
 		return false, fmt.Errorf("domain is empty")
 	}
+	if !isASCII(value) {
+		ascii, err := idna.Lookup.ToASCII(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid internationalized domain: %s", value)
+		}
+		value = ascii
+	}
 	if len(value) > 255 {
 		return false, fmt.Errorf("domain length exceeds 255 characters: %s", value)
 	}
@@ -195,10 +209,66 @@ func ValidMAC(value string) (bool, error) {
 }
 
 // ValidUUID는 문자열이 유효한 UUID(v4 등)인지 확인합니다.
-// - 간단히 정규식 사용. 필요에 따라 UUID 버전도 체크 가능.
+// - 8-4-4-4-12 하이픈 형식 외에 중괄호("{...}"), "urn:uuid:" 접두사, 하이픈 없는 32자리 형식도 허용합니다.
 func ValidUUID(value string) (bool, error) {
-	if !uuidRegex.MatchString(value) {
+	if _, ok := parseUUID(value); !ok {
 		return false, fmt.Errorf("invalid UUID: %s", value)
 	}
 	return true, nil
 }
+
+// NormalizeDomain은 도메인을 소문자 퓨니코드(IDNA) 형식으로 바꾸고 끝의 "."을 제거합니다.
+// 예: "Example.COM" → "example.com", "한국.KR" → "xn--3e0b707e.kr"
+func NormalizeDomain(value string) (string, error) {
+	ascii, err := idna.Lookup.ToASCII(strings.TrimSuffix(value, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid domain: %s", value)
+	}
+	return strings.ToLower(ascii), nil
+}
+
+// NormalizeUUID는 UUID를 소문자 8-4-4-4-12 하이픈 형식으로 바꿉니다.
+// 예: "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}", "urn:uuid:6ba7b810-…", "6ba7b8109dad11d180b400c04fd430c8"
+// → "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+func NormalizeUUID(value string) (string, error) {
+	hex, ok := parseUUID(value)
+	if !ok {
+		return "", fmt.Errorf("invalid UUID: %s", value)
+	}
+	return hex[0:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:32], nil
+}
+
+// parseUUID는 UUID 표기에서 하이픈, 중괄호, "urn:uuid:" 접두사를 제거한 소문자 16진수 32자리를 반환합니다.
+func parseUUID(value string) (string, bool) {
+	if len(value) > 9 && strings.EqualFold(value[:9], "urn:uuid:") {
+		value = value[9:]
+	} else if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
+		value = value[1 : len(value)-1]
+	}
+	switch len(value) {
+	case 36:
+		if value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+			return "", false
+		}
+		value = value[0:8] + value[9:13] + value[14:18] + value[19:23] + value[24:]
+	case 32:
+	default:
+		return "", false
+	}
+	value = strings.ToLower(value)
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return "", false
+		}
+	}
+	return value, true
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
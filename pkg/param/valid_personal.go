@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
@@ -13,12 +14,15 @@ func init() {
 	RegisterValidFunc(PASSWORD, ValidNormalPassword)
 	RegisterValidFunc(PASSWORD_STRONG, ValidStrongPassword)
 	RegisterValidFunc(CREDITCARD, ValidCreditcard)
+
+	RegisterNormalizeFunc(EMAIL, NormalizeEmail)
+	RegisterNormalizeFunc(CREDITCARD, NormalizeCreditcard)
 }
 
 var (
 	regexName       = regexp.MustCompile(`^[가-힣a-zA-Z0-9 ]+$`)
 	regexPassword   = regexp.MustCompile(`^[A-Za-z0-9!@#$%^&*()_+\-=\\|{}\[\]:;"'<>,.?/~` + "`" + `]+$`)
-	regexCreditcard = regexp.MustCompile(`^[0-9]{4}-?[0-9]{4}-?[0-9]{4}-?[0-9]{4}$`)
+	regexCreditcard = regexp.MustCompile(`^[0-9]+(?:[- ][0-9]+)*$`)
 )
 
 func ValidName(value string) (bool, error) {
@@ -31,6 +35,25 @@ func ValidEmail(value string) (bool, error) {
 	return err == nil, err
 }
 
+// NormalizeEmail은 이메일에서 표시 이름을 빼고 주소만 남겨 소문자로 바꾸며,
+// 국제화 도메인은 퓨니코드로 바꿉니다.
+// 예: "Hong <Hong@Example.COM>" → "hong@example.com", "user@한국.kr" → "user@xn--3e0b707e.kr"
+func NormalizeEmail(value string) (string, error) {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return "", err
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	if at < 0 {
+		return "", fmt.Errorf("invalid email address: %s", value)
+	}
+	domain, err := NormalizeDomain(addr.Address[at+1:])
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(addr.Address[:at]) + "@" + domain, nil
+}
+
 // - 특수문자, 대소문자, 숫자 포함 8글자 이상
 func ValidNormalPassword(value string) (bool, error) {
 	return ValidPassword(value, 8)
@@ -42,16 +65,34 @@ func ValidStrongPassword(value string) (bool, error) {
 }
 
 // ValidCreditcard
-// - 신용카드번호 (숫자 16자리)
+// - 신용카드번호 (숫자 13-19자리, 하이픈/공백 구분 허용)
+// - 카드 브랜드(Visa, Mastercard, Amex, JCB, BC, UnionPay 등)별 번호 대역과 자릿수, Luhn 검증번호 검사
 func ValidCreditcard(value string) (bool, error) {
 	if !regexCreditcard.MatchString(value) {
-		return false, fmt.Errorf(
-			"invalid credit card number format: must be 16-digit numeric",
-		)
+		return false, fmt.Errorf("%w: credit card number must be numeric", ErrFormat)
+	}
+	digits := cardDigits(value)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false, fmt.Errorf("%w: credit card number must be 13-19 digits", ErrCardLength)
+	}
+	if _, err := CardBrand(digits); err != nil {
+		return false, err
+	}
+	if !Luhn(digits) {
+		return false, fmt.Errorf("%w: credit card number check digit", ErrChecksum)
 	}
 	return true, nil
 }
 
+// NormalizeCreditcard는 카드 번호에서 하이픈과 공백을 제거합니다.
+func NormalizeCreditcard(value string) (string, error) {
+	return cardDigits(value), nil
+}
+
+func cardDigits(value string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(value)
+}
+
 // ValidPassword
 // - 특수문자, 대소문자, 숫자 포함 8글자 이상
 func ValidPassword(value string, ln int) (bool, error) {
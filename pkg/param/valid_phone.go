@@ -4,13 +4,57 @@ package param
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
 func init() {
 	RegisterValidFunc(PHONE_KR, ValidPhoneKR)
+	RegisterValidFunc(MOBILE_KR, ValidMobileKR)
 	RegisterValidFunc(PHONE, ValidPhone)
 	RegisterValidFunc(PHONE_E164, ValidPhoneE164)
+
+	// 국가별 타입: 전용 검증 함수가 없으면 ValidPhone으로 검증하고 E.164로 정규화
+	for typ, code := range phoneCountryCodes {
+		if _, ok := lookupValidFunc(typ); !ok {
+			RegisterValidFunc(typ, ValidPhone)
+		}
+		RegisterNormalizeFunc(typ, NormalizePhone(code))
+	}
+	RegisterNormalizeFunc(PHONE_E164, NormalizePhoneE164)
+}
+
+// 타입별 국가 전화 코드 (PHONE_* 상수 값은 대부분 국가 코드와 같음)
+var phoneCountryCodes = map[uint32]string{
+	PHONE_NANP: "1", PHONE_US: "1", PHONE_CA: "1",
+	PHONE_RU: "7", PHONE_FR: "33", PHONE_ES: "34", PHONE_IT: "39", PHONE_GB: "44",
+	PHONE_DE: "49", PHONE_BR: "55", PHONE_MY: "60", PHONE_AU: "61", PHONE_ID: "62",
+	PHONE_PH: "63", PHONE_TH: "66", PHONE_JP: "81", PHONE_KR: "82", MOBILE_KR: "82",
+	PHONE_VN: "84", PHONE_CN: "86", PHONE_TR: "90", PHONE_IN: "91", PHONE_PK: "92",
+	PHONE_IR: "98", PHONE_BD: "880", PHONE_JO: "962", PHONE_KW: "965", PHONE_SA: "966",
+	PHONE_AE: "971", PHONE_IL: "972", PHONE_AZ: "994", PHONE_UZ: "998",
+}
+
+// phoneTrunk는 국내 번호 앞에 붙이는 트렁크 프리픽스로, 국제 번호로 바꿀 때 제거합니다.
+type phoneTrunk struct {
+	prefix string // 트렁크 프리픽스 (없으면 "")
+	length int    // 트렁크 프리픽스를 포함한 국내 번호 자릿수 (0이면 자릿수와 관계없이 제거)
+}
+
+// 국가 코드별 트렁크 프리픽스. 목록에 없는 국가는 국제 형식 번호만 정규화합니다.
+var phoneTrunks = map[string]phoneTrunk{
+	"1":  {},                        // NANP: 앞자리 1은 국가 코드로 처리
+	"7":  {prefix: "8", length: 11}, // 러시아, 카자흐스탄: 8 495 123-45-67 (지역 번호도 8로 시작할 수 있어 자릿수로 구분)
+	"33": {prefix: "0"}, "44": {prefix: "0"}, "49": {prefix: "0"}, "55": {prefix: "0"},
+	"34": {}, // 스페인: 트렁크 프리픽스 없음
+	"39": {}, // 이탈리아: 앞자리 0은 번호의 일부로 국제 번호에서도 유지
+	"60": {prefix: "0"}, "61": {prefix: "0"}, "62": {prefix: "0"}, "63": {prefix: "0"},
+	"66": {prefix: "0"}, "81": {prefix: "0"}, "82": {prefix: "0"}, "84": {prefix: "0"},
+	"86": {prefix: "0"}, "90": {prefix: "0"}, "91": {prefix: "0"}, "92": {prefix: "0"},
+	"98": {prefix: "0"}, "880": {prefix: "0"}, "962": {prefix: "0"}, "966": {prefix: "0"},
+	"965": {}, // 쿠웨이트: 트렁크 프리픽스 없음
+	"971": {prefix: "0"}, "972": {prefix: "0"}, "994": {prefix: "0"},
+	"998": {}, // 우즈베키스탄: 트렁크 프리픽스 없음
 }
 
 var (
@@ -80,3 +124,70 @@ func ValidMobileKR(value string) (bool, error) {
 	}
 	return true, nil
 }
+
+// NormalizePhone은 국가 코드 code의 전화번호를 E.164 형식(+국가코드+번호, 숫자만)으로 바꾸는 함수를 반환합니다.
+//   - "+82-10-1234-5678", "82 10 1234 5678", "0082-10-1234-5678" → "+821012345678"
+//   - 국내 형식은 국가별 트렁크 프리픽스(phoneTrunks)를 빼고 국가 코드를 붙임
+//     예: "010-1234-5678"(+82) → "+821012345678", "8 495 123-45-67"(+7) → "+74951234567", 이탈리아는 0 유지
+//   - 국제 형식에 남은 앞자리 0("+82-010-...")도 제거 (트렁크 프리픽스가 0인 국가)
+//   - 트렁크 프리픽스를 모르는 국가는 국내 형식 번호를 거부
+func NormalizePhone(code string) NormalizeFunc {
+	trunk, known := phoneTrunks[code]
+	return func(value string) (string, error) {
+		international := strings.HasPrefix(strings.TrimSpace(value), "+")
+		digits := phoneDigits(value)
+		domestic := false
+		switch {
+		case strings.HasPrefix(digits, "00"+code):
+			digits = digits[2+len(code):]
+		case international || (strings.HasPrefix(digits, code) && !trunk.matches(digits)):
+			if !strings.HasPrefix(digits, code) {
+				return "", fmt.Errorf("phone number is not in country code +%s", code)
+			}
+			digits = digits[len(code):]
+		default:
+			domestic = true
+		}
+		switch {
+		case domestic && !known:
+			return "", fmt.Errorf("phone number must be in international format (+%s)", code)
+		case domestic && trunk.matches(digits):
+			digits = digits[len(trunk.prefix):]
+		case !domestic && trunk.prefix == "0":
+			digits = strings.TrimPrefix(digits, "0")
+		}
+		return formatE164(code + digits)
+	}
+}
+
+// matches는 국내 번호 digits가 트렁크 프리픽스로 시작하는지 반환합니다.
+func (t phoneTrunk) matches(digits string) bool {
+	if t.prefix == "" || !strings.HasPrefix(digits, t.prefix) {
+		return false
+	}
+	return t.length == 0 || len(digits) == t.length
+}
+
+// NormalizePhoneE164는 E.164 번호에서 구분자를 빼고 "+"를 붙입니다.
+func NormalizePhoneE164(value string) (string, error) {
+	return formatE164(phoneDigits(value))
+}
+
+// phoneDigits는 전화번호에서 숫자만 남깁니다.
+func phoneDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func formatE164(digits string) (string, error) {
+	// 국가 코드를 포함해 최대 15자리, 0으로 시작할 수 없음
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", fmt.Errorf("invalid E.164 phone number: %s", "+"+digits)
+	}
+	return "+" + digits, nil
+}
@@ -0,0 +1,58 @@
+// parkjunwoo.com/microstral/pkg/param/valid_phone_test.go
+package param
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		code  string
+		value string
+		want  string // ""이면 오류
+	}{
+		{"82", "010-1234-5678", "+821012345678"},
+		{"82", "+82-10-1234-5678", "+821012345678"},
+		{"82", "+82-010-1234-5678", "+821012345678"},
+		{"82", "0082-10-1234-5678", "+821012345678"},
+		{"82", "82 10 1234 5678", "+821012345678"},
+		{"82", "+1-212-555-1234", ""},
+
+		{"7", "8 (495) 123-45-67", "+74951234567"},
+		{"7", "8 812 123-45-67", "+78121234567"},
+		{"7", "+7 812 123-45-67", "+78121234567"}, // 국제 형식의 지역 번호 8은 유지
+		{"7", "7 916 123-45-67", "+79161234567"},
+		{"7", "916 123-45-67", "+79161234567"},
+		{"7", "812 123-45-67", "+78121234567"}, // 10자리는 트렁크 프리픽스 없는 번호
+
+		{"1", "(212) 555-1234", "+12125551234"},
+		{"1", "1-212-555-1234", "+12125551234"},
+		{"44", "020 7946 0018", "+442079460018"},
+		{"39", "06 1234 5678", "+390612345678"},
+		{"39", "+39 06 1234 5678", "+390612345678"},
+		{"34", "912 345 678", "+34912345678"},
+		{"998", "90 123 45 67", "+998901234567"},
+
+		// 트렁크 프리픽스를 모르는 국가는 국제 형식만 허용
+		{"358", "+358 40 1234567", "+358401234567"},
+		{"358", "040 1234567", ""},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.code)(tt.value)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("NormalizePhone(%s)(%q) = %q, want error", tt.code, tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizePhone(%s)(%q) = %q, %v, want %q", tt.code, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestPhoneTrunksCoverCountryTypes(t *testing.T) {
+	for typ, code := range phoneCountryCodes {
+		if _, ok := phoneTrunks[code]; !ok {
+			t.Errorf("phoneTrunks has no entry for %s (+%s)", TypeName(typ), code)
+		}
+	}
+}
@@ -13,8 +13,14 @@ func init() {
 	RegisterValidFunc(UNIX_TIME, ValidUnixTime)
 	RegisterValidFunc(UTC_TIME, ValidUTCTime)
 	RegisterValidFunc(DURATION, ValidDuration)
+
+	RegisterNormalizeFunc(DATE_TIME, NormalizeDateTime)
+	RegisterNormalizeFunc(UTC_TIME, NormalizeDateTime)
 }
 
+// 시간대가 없는 날짜시간 형식 (UTC로 해석)
+const layoutDateTime = "2006-01-02T15:04:05"
+
 // ValidDate는 문자열이 "2006-01-02" 형식의 유효한 날짜인지 확인합니다.
 func ValidDate(value string) (bool, error) {
 	_, err := time.Parse("2006-01-02", value)
@@ -27,10 +33,10 @@ func ValidTime(value string) (bool, error) {
 	return err == nil, err
 }
 
-// ValidDateTime은 문자열이 "2006-01-02T15:04:05" 형식의 유효한 날짜시간인지 확인합니다.
+// ValidDateTime은 문자열이 "2006-01-02T15:04:05" 또는 RFC3339 형식의 유효한 날짜시간인지 확인합니다.
 // (필요에 따라 다른 형식으로 변경할 수 있습니다.)
 func ValidDateTime(value string) (bool, error) {
-	_, err := time.Parse("2006-01-02T15:04:05", value)
+	_, err := parseDateTime(value)
 	return err == nil, err
 }
 
@@ -52,3 +58,20 @@ func ValidDuration(value string) (bool, error) {
 	_, err := time.ParseDuration(value)
 	return err == nil, err
 }
+
+// NormalizeDateTime은 날짜시간을 UTC RFC3339 형식으로 바꿉니다. 시간대가 없으면 UTC로 봅니다.
+// 예: "2024-03-01T09:00:00+09:00" → "2024-03-01T00:00:00Z"
+func NormalizeDateTime(value string) (string, error) {
+	t, err := parseDateTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339Nano), nil
+}
+
+func parseDateTime(value string) (time.Time, error) {
+	if t, err := time.Parse(layoutDateTime, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,99 @@
+// parkjunwoo.com/microstral/pkg/param/validator.go
+package param
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TagPrefix는 검증기 태그 이름 앞에 붙는 접두사입니다.
+// 모든 param 타입은 접두사를 붙인 태그(param_phone_kr)로 등록하고, 기본 태그와 겹치지 않는 타입은
+// 접두사 없는 태그(phone_kr, ssn_kr)로도 등록합니다.
+const TagPrefix = "param_"
+
+// builtinTags는 go-playground/validator 기본 태그와 이름이 겹치는 param 타입입니다.
+// 기본 태그의 의미가 바뀌지 않도록 이 타입들은 접두사를 붙인 태그로만 등록합니다.
+//   - base64, email, file, html, json, jwt, url, uuid
+//   - ip, ipv4, ipv6, mac
+//   - rgb, rgba, hsl, hsla
+var builtinTags = map[string]bool{
+	"base64": true, "email": true, "file": true, "html": true, "json": true, "jwt": true, "url": true, "uuid": true,
+	"ip": true, "ipv4": true, "ipv6": true, "mac": true,
+	"rgb": true, "rgba": true, "hsl": true, "hsla": true,
+}
+
+var (
+	validatorsMu sync.Mutex
+	validators   []*validator.Validate
+)
+
+// RegisterValidator는 등록된 모든 타입을 타입 이름의 소문자(phone_kr, ssn_kr 등)와
+// TagPrefix를 붙인 이름(param_phone_kr, param_email 등)으로 go-playground/validator 태그에 등록합니다.
+// 이후 RegisterValidFunc/RegisterType으로 추가한 타입도 같은 검증기에 자동으로 등록됩니다.
+// 기본 태그와 겹치는 타입(builtinTags)은 접두사를 붙인 이름으로만 등록하므로 email, url, uuid 등은 그대로 유지됩니다.
+// validator.Validate는 검증 중 태그 등록이 안전하지 않으므로 타입 등록은 서버 시작 전에 마쳐야 합니다.
+func RegisterValidator(v *validator.Validate) error {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	for _, registered := range validators {
+		if registered == v {
+			return nil
+		}
+	}
+
+	validFuncsMu.RLock()
+	types := make([]uint32, 0, len(validFuncs))
+	for typ := range validFuncs {
+		types = append(types, typ)
+	}
+	validFuncsMu.RUnlock()
+
+	for _, typ := range types {
+		if err := registerTag(v, typ); err != nil {
+			return err
+		}
+	}
+	validators = append(validators, v)
+	return nil
+}
+
+// registerValidatorTag는 RegisterValidator로 등록된 모든 검증기에 타입의 태그를 등록합니다.
+func registerValidatorTag(typ uint32) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	for _, v := range validators {
+		_ = registerTag(v, typ)
+	}
+}
+
+func registerTag(v *validator.Validate, typ uint32) error {
+	name := strings.ToLower(TypeName(typ))
+	if name == "" {
+		return nil
+	}
+	fn := validatorFunc(typ)
+	if err := v.RegisterValidation(TagPrefix+name, fn); err != nil {
+		return err
+	}
+	if builtinTags[name] {
+		return nil
+	}
+	return v.RegisterValidation(name, fn)
+}
+
+// validatorFunc는 타입의 검증 함수를 go-playground/validator 함수로 감쌉니다.
+// 검증 함수는 호출 시점에 찾으므로 RegisterValidFunc로 교체한 함수도 바로 적용됨
+func validatorFunc(typ uint32) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value, ok := scalarString(fl.Field())
+		if !ok {
+			return false
+		}
+		// 빈 값도 검증 함수로 검사 (빈 값을 허용하려면 omitempty 태그 사용)
+		p := Param{Type: typ, Required: true}
+		valid, err := p.Validate(value)
+		return valid && err == nil
+	}
+}
@@ -0,0 +1,78 @@
+// parkjunwoo.com/microstral/pkg/param/validator_test.go
+package param
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func newTestValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+	v := validator.New()
+	if err := RegisterValidator(v); err != nil {
+		t.Fatalf("RegisterValidator() error = %v", err)
+	}
+	return v
+}
+
+func TestRegisterValidatorTags(t *testing.T) {
+	v := newTestValidator(t)
+	tests := []struct {
+		tag   string
+		value string
+		ok    bool
+	}{
+		{"required,phone_kr", "02-123-4567", true},
+		{"required,phone_kr", "abc", false},
+		{"ssn_kr", "900101-1234568", true},
+		{"ssn_kr", "900101-1234567", false},
+		{"required,param_phone_kr", "02-123-4567", true},
+		{"param_ssn_kr", "900101-1234567", false},
+
+		// 기본 태그는 그대로, param 타입은 접두사로 사용
+		{"uuid", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", false},
+		{"param_uuid", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", true},
+		{"file", "report.pdf", false}, // 기본 태그는 존재하는 파일만 허용
+		{"param_file", "report.pdf", true},
+	}
+	for _, tt := range tests {
+		err := v.Var(tt.value, tt.tag)
+		if tt.ok && err != nil {
+			t.Errorf("Var(%q, %q) error = %v, want nil", tt.value, tt.tag, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("Var(%q, %q) = nil, want error", tt.value, tt.tag)
+		}
+	}
+}
+
+// builtinTags는 검증기 기본 태그와 겹치는 타입 이름을 빠짐없이 담아야 함 (검증기 버전이 바뀌면 확인)
+func TestBuiltinTags(t *testing.T) {
+	typeNamesMu.RLock()
+	names := make([]string, 0, len(typeNames))
+	for name := range typeNames {
+		names = append(names, strings.ToLower(name))
+	}
+	typeNamesMu.RUnlock()
+
+	for _, name := range names {
+		if got := hasBuiltinTag(name); got != builtinTags[name] {
+			t.Errorf("builtinTags[%q] = %v, but validator built-in tag exists = %v", name, builtinTags[name], got)
+		}
+	}
+}
+
+// hasBuiltinTag는 새 검증기에 tag가 정의되어 있는지 반환합니다.
+// 정의되지 않은 태그는 "Undefined validation function" panic이 발생합니다.
+func hasBuiltinTag(tag string) (defined bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			defined = !strings.Contains(fmt.Sprint(r), "Undefined validation function")
+		}
+	}()
+	_ = validator.New().Var("", tag)
+	return true
+}
@@ -0,0 +1,197 @@
+// parkjunwoo.com/microstral/pkg/problem/problem.go
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/param"
+	"parkjunwoo.com/microstral/pkg/requestid"
+)
+
+// ContentType은 RFC 9457 문제 상세 응답의 미디어 타입입니다.
+const ContentType = "application/problem+json"
+
+// 자주 쓰는 오류 코드
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict"
+	CodeTooLarge         = "request_too_large"
+	CodeTimeout          = "timeout"
+	CodeInternal         = "internal_error"
+)
+
+// FieldError는 요청 필드 하나의 검증 오류입니다.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// Error는 RFC 9457 문제 상세(application/problem+json)로 응답하는 오류입니다.
+// 표준 멤버(type, title, status, detail, instance)에 기계가 읽을 수 있는 code,
+// 필드별 오류 errors, 추적용 request_id를 확장 멤버로 추가합니다.
+type Error struct {
+	Type      string       `json:"type,omitempty"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+
+	// 응답에는 포함하지 않는 내부 원인 (로그용)
+	cause error
+}
+
+// New는 HTTP 상태 코드, 오류 코드, 상세 메시지로 오류를 생성합니다.
+func New(status int, code string, detail string) *Error {
+	return &Error{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// BadRequest는 400 오류를 생성합니다.
+func BadRequest(code string, detail string) *Error {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+// Invalid는 필드 하나의 검증 오류로 400 오류를 생성합니다.
+func Invalid(field string, code string, message string) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, "request validation failed").WithField(field, code, message)
+}
+
+// Unauthorized는 401 오류를 생성합니다.
+func Unauthorized(detail string) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, detail)
+}
+
+// Forbidden은 403 오류를 생성합니다.
+func Forbidden(code string, detail string) *Error {
+	return New(http.StatusForbidden, code, detail)
+}
+
+// NotFound는 404 오류를 생성합니다.
+func NotFound(detail string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, detail)
+}
+
+// Internal은 500 오류를 생성합니다. cause는 로그에만 남고 응답에는 포함되지 않습니다.
+func Internal(cause error) *Error {
+	return New(http.StatusInternalServerError, CodeInternal, "").WithCause(cause)
+}
+
+// WithField는 필드 오류를 추가합니다.
+func (e *Error) WithField(field string, code string, message string) *Error {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code, Message: message})
+	return e
+}
+
+// WithCause는 로그용 내부 원인을 설정합니다.
+func (e *Error) WithCause(err error) *Error {
+	e.cause = err
+	return e
+}
+
+// WithType은 문제 유형 URI를 설정합니다.
+func (e *Error) WithType(uri string) *Error {
+	e.Type = uri
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Code)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	for _, f := range e.Errors {
+		msg += fmt.Sprintf(" [%s: %s]", f.Field, f.Code)
+	}
+	if e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// From은 err를 *Error로 변환합니다.
+// *Error를 감싼 오류는 그대로 꺼내고, 본문 크기 초과는 413, param.Bind의 검증/해석 오류는 400,
+// 그 외는 500으로 변환합니다.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return New(http.StatusRequestEntityTooLarge, CodeTooLarge, fmt.Sprintf("request body must be at most %d bytes", maxErr.Limit)).WithCause(err)
+	}
+	var verrs param.ValidationErrors
+	if errors.As(err, &verrs) {
+		e := New(http.StatusBadRequest, CodeValidationFailed, "request validation failed").WithCause(err)
+		for _, f := range verrs {
+			e.WithField(f.Field, f.Code, f.Message)
+		}
+		return e
+	}
+	var bindErr *param.BindError
+	if errors.As(err, &bindErr) {
+		return BadRequest(CodeInvalidRequest, "request could not be parsed").WithCause(err)
+	}
+	// 요청 기한(middleware.Timeout) 초과로 중단된 DB, HTTP 호출 등
+	if errors.Is(err, context.DeadlineExceeded) {
+		return New(http.StatusGatewayTimeout, CodeTimeout, "request did not complete in time").WithCause(err)
+	}
+	return Internal(err)
+}
+
+// Abort는 오류를 컨텍스트에 기록하고 이후 핸들러 실행을 중단합니다.
+// 응답 본문은 middleware.Errors가 렌더링합니다.
+func Abort(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+	// 상태 코드만 기록하고 헤더는 아직 쓰지 않음 (본문은 middleware.Errors가 렌더링)
+	c.Status(From(err).Status)
+}
+
+// Render는 오류를 application/problem+json으로 응답합니다.
+// instance와 request_id가 비어 있으면 요청 경로와 요청 ID로 채웁니다.
+func Render(c *gin.Context, err error) {
+	Write(c.Writer, c.Request, err)
+}
+
+// Write는 gin 밖의 http.Handler에서 오류를 application/problem+json으로 응답합니다.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	e := *From(err)
+	if e.Instance == "" {
+		e.Instance = r.URL.Path
+	}
+	if ids, ok := requestid.FromContext(r.Context()); ok && e.RequestID == "" {
+		e.RequestID = ids.RequestID
+	}
+	body, mErr := json.Marshal(e)
+	if mErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(e.Status)
+	w.Write(body)
+}
@@ -0,0 +1,225 @@
+// parkjunwoo.com/microstral/pkg/report/report.go
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"parkjunwoo.com/microstral/pkg/env"
+)
+
+// Reporter는 처리되지 않은 오류(패닉 등)를 외부 오류 수집 서비스로 전달합니다.
+type Reporter interface {
+	Report(ctx context.Context, event *Event) error
+}
+
+// Event는 Sentry 이벤트 페이로드 형식의 오류 이벤트입니다.
+// https://develop.sentry.dev/sdk/data-model/event-payloads/
+type Event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Exception   *Exceptions       `json:"exception,omitempty"`
+	Request     *Request          `json:"request,omitempty"`
+	User        *User             `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// Exceptions는 이벤트의 예외 목록입니다.
+type Exceptions struct {
+	Values []Exception `json:"values"`
+}
+
+// Exception은 예외 하나와 발생 시점의 스택입니다.
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace는 호출 순서(가장 바깥 프레임이 먼저)의 스택 프레임 목록입니다.
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Frame은 스택 프레임 하나입니다.
+type Frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Request는 이벤트가 발생한 HTTP 요청 정보입니다. 인증 헤더와 쿠키는 담지 않습니다.
+type Request struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	QueryString string `json:"query_string,omitempty"`
+}
+
+// User는 이벤트가 발생한 요청의 사용자입니다.
+type User struct {
+	ID    string `json:"id,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// NewEvent는 서버 정보(HOST 또는 호스트 이름, SENTRY_RELEASE, SENTRY_ENVIRONMENT)를 채운 새 이벤트를 생성합니다.
+func NewEvent(level string, message string) *Event {
+	return &Event{
+		EventID:     newEventID(),
+		Timestamp:   time.Now().UTC(),
+		Level:       level,
+		Platform:    "go",
+		ServerName:  serverName(),
+		Release:     env.GetEnv("SENTRY_RELEASE", ""),
+		Environment: env.GetEnv("SENTRY_ENVIRONMENT", ""),
+		Message:     message,
+		Tags:        map[string]string{},
+	}
+}
+
+// NewPanicEvent는 recover()로 얻은 값과 현재 고루틴의 스택으로 fatal 이벤트를 생성합니다.
+// skip은 스택에서 제외할 호출자 수입니다. (recover를 호출한 함수 기준 0)
+func NewPanicEvent(recovered any, skip int) *Event {
+	value := fmt.Sprint(recovered)
+	e := NewEvent("fatal", "panic: "+value)
+	e.Exception = &Exceptions{Values: []Exception{{
+		Type:       fmt.Sprintf("%T", recovered),
+		Value:      value,
+		Stacktrace: stacktrace(skip + 2),
+	}}}
+	return e
+}
+
+// Envelope는 이벤트를 Sentry 엔벨로프(application/x-sentry-envelope) 형식으로 인코딩합니다.
+// https://develop.sentry.dev/sdk/envelopes/
+func Envelope(event *Event, dsn string) ([]byte, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	header := map[string]string{
+		"event_id": event.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if dsn != "" {
+		header["dsn"] = dsn
+	}
+	envelopeHeader, _ := json.Marshal(header)
+	itemHeader, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+
+	var buf bytes.Buffer
+	buf.Write(envelopeHeader)
+	buf.WriteByte('\n')
+	buf.Write(itemHeader)
+	buf.WriteByte('\n')
+	buf.Write(payload)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// FromEnv는 환경 변수 설정으로 Reporter를 생성합니다. 설정이 없으면 nil을 반환합니다.
+// - ERROR_REPORTER: sentry, http, file 또는 none (기본값: SENTRY_DSN이 있으면 sentry, 없으면 none)
+// - SENTRY_DSN: sentry 전송 대상 DSN
+// - ERROR_REPORT_URL: http 전송 대상 URL (엔벨로프를 POST)
+// - ERROR_REPORT_FILE: file 기록 경로 (기본값 errors.envelope)
+func FromEnv() (Reporter, error) {
+	dsn := env.GetEnv("SENTRY_DSN", "")
+	kind := "none"
+	if dsn != "" {
+		kind = "sentry"
+	}
+	switch kind = strings.ToLower(env.GetEnv("ERROR_REPORTER", kind)); kind {
+	case "none", "":
+		return nil, nil
+	case "sentry":
+		return NewSentry(dsn)
+	case "http":
+		url := env.GetEnv("ERROR_REPORT_URL", "")
+		if url == "" {
+			return nil, fmt.Errorf("ERROR_REPORT_URL is required for http error reporter")
+		}
+		return NewHTTP(url), nil
+	case "file":
+		return NewFile(env.GetEnv("ERROR_REPORT_FILE", "errors.envelope")), nil
+	default:
+		return nil, fmt.Errorf("unsupported error reporter: %s", kind)
+	}
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// stacktrace는 현재 고루틴의 스택을 Sentry 순서(가장 바깥 프레임이 먼저)로 반환합니다.
+func stacktrace(skip int) *Stacktrace {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var list []Frame
+	for {
+		f, more := frames.Next()
+		// panic 처리 중인 런타임 프레임은 제외
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			module, function := splitFunction(f.Function)
+			list = append(list, Frame{
+				Function: function,
+				Module:   module,
+				AbsPath:  f.File,
+				Lineno:   f.Line,
+				InApp:    inApp(module, f.File),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return &Stacktrace{Frames: list}
+}
+
+// splitFunction은 "parkjunwoo.com/microstral/pkg/auth.(*UserModel).Get"을
+// 모듈 "parkjunwoo.com/microstral/pkg/auth"와 함수 "(*UserModel).Get"으로 나눕니다.
+func splitFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// inApp은 표준 라이브러리(경로 첫 요소에 점이 없음)와 모듈 캐시의 의존성 프레임을 제외합니다.
+func inApp(module string, file string) bool {
+	first, _, _ := strings.Cut(module, "/")
+	return strings.Contains(first, ".") && !strings.Contains(file, "/pkg/mod/")
+}
+
+func serverName() string {
+	if host := env.GetEnv("HOST", ""); host != "" {
+		return host
+	}
+	host, _ := os.Hostname()
+	return host
+}
@@ -0,0 +1,106 @@
+// parkjunwoo.com/microstral/pkg/report/sentry.go
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"parkjunwoo.com/microstral/pkg/file"
+)
+
+// EnvelopeContentType은 Sentry 엔벨로프의 미디어 타입입니다.
+const EnvelopeContentType = "application/x-sentry-envelope"
+
+// HTTP는 이벤트 엔벨로프를 URL로 POST하는 Reporter입니다.
+// Sentry 대신 사내 수집기나 로컬 릴레이로 보낼 때 사용합니다.
+type HTTP struct {
+	URL    string
+	Header http.Header
+	Client *http.Client
+
+	dsn string
+}
+
+// NewHTTP는 url로 엔벨로프를 전송하는 Reporter를 생성합니다.
+func NewHTTP(url string) *HTTP {
+	return &HTTP{
+		URL:    url,
+		Header: http.Header{},
+		Client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// NewSentry는 Sentry DSN(https://<key>@<host>/<project>)으로 전송하는 Reporter를 생성합니다.
+func NewSentry(dsn string) (*HTTP, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN")
+	}
+	path := strings.Trim(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	prefix, project := "", path
+	if slash >= 0 {
+		prefix, project = "/"+path[:slash], path[slash+1:]
+	}
+	if project == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: missing project ID")
+	}
+
+	h := NewHTTP(fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, project))
+	h.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=microstral/1.0", u.User.Username()))
+	h.dsn = dsn
+	return h, nil
+}
+
+// Report는 이벤트를 엔벨로프로 인코딩하여 전송합니다. 2xx 이외의 응답은 오류로 반환합니다.
+func (h *HTTP) Report(ctx context.Context, event *Event) error {
+	body, err := Envelope(event, h.dsn)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range h.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", EnvelopeContentType)
+
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send error report: %w", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("error report rejected: %s", res.Status)
+	}
+	return nil
+}
+
+// File은 이벤트 엔벨로프를 파일에 이어 쓰는 Reporter입니다.
+// 오류 수집 서비스가 없는 로컬/개발 환경이나 사이드카가 파일을 수집하는 환경에서 사용합니다.
+type File struct {
+	Path string
+}
+
+// NewFile은 path에 엔벨로프를 기록하는 Reporter를 생성합니다.
+func NewFile(path string) *File {
+	return &File{Path: path}
+}
+
+// Report는 이벤트 엔벨로프를 파일 끝에 추가합니다. 여러 프로세스가 같은 파일을 써도 파일 락으로 보호됩니다.
+func (f *File) Report(ctx context.Context, event *Event) error {
+	body, err := Envelope(event, "")
+	if err != nil {
+		return err
+	}
+	return file.AppendFile(f.Path, body, 0o640)
+}
@@ -0,0 +1,82 @@
+// parkjunwoo.com/microstral/pkg/requestid/requestid.go
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+const (
+	HeaderRequestID   = "X-Request-ID" // 요청 ID 헤더
+	HeaderTraceparent = "traceparent"  // W3C Trace Context 헤더
+)
+
+var (
+	regexRequestID   = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+	regexTraceparent = regexp.MustCompile(`^([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+)
+
+type ctxKey struct{}
+
+// IDs는 하나의 요청에 대한 상관관계 식별자입니다.
+type IDs struct {
+	RequestID   string // X-Request-ID
+	Traceparent string // 이 서비스의 요청을 부모로 하는 traceparent
+}
+
+// NewContext는 상관관계 식별자를 컨텍스트에 저장합니다.
+func NewContext(ctx context.Context, ids IDs) context.Context {
+	return context.WithValue(ctx, ctxKey{}, ids)
+}
+
+// FromContext는 컨텍스트에 저장된 상관관계 식별자를 반환합니다.
+func FromContext(ctx context.Context) (IDs, bool) {
+	if ctx == nil {
+		return IDs{}, false
+	}
+	ids, ok := ctx.Value(ctxKey{}).(IDs)
+	return ids, ok
+}
+
+// Generate는 새 요청 ID(32자리 16진수)를 생성합니다.
+func Generate() string {
+	return randomHex(16)
+}
+
+// Valid는 외부에서 받은 요청 ID가 로그와 헤더에 그대로 사용해도 안전한 형식인지 확인합니다.
+func Valid(id string) bool {
+	return regexRequestID.MatchString(id)
+}
+
+// Traceparent는 수신한 traceparent를 이어받아 이 서비스의 새 parent-id로 traceparent를 만듭니다.
+// 수신한 값이 없거나 올바르지 않으면 새 trace-id로 시작합니다.
+func Traceparent(incoming string) string {
+	traceID, flags, ok := ParseTraceparent(incoming)
+	if !ok {
+		traceID = randomHex(16)
+		flags = "01"
+	}
+	return "00-" + traceID + "-" + randomHex(8) + "-" + flags
+}
+
+// ParseTraceparent는 traceparent에서 trace-id와 trace-flags를 추출합니다.
+func ParseTraceparent(value string) (traceID string, flags string, ok bool) {
+	m := regexTraceparent.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return "", "", false
+	}
+	// 버전 ff와 모두 0인 trace-id/parent-id는 유효하지 않음
+	if m[1] == "ff" || m[2] == strings.Repeat("0", 32) || m[3] == strings.Repeat("0", 16) {
+		return "", "", false
+	}
+	return m[2], m[4], true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
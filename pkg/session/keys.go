@@ -0,0 +1,151 @@
+// parkjunwoo.com/microstral/pkg/session/keys.go
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"parkjunwoo.com/microstral/pkg/env"
+	"parkjunwoo.com/microstral/pkg/file"
+)
+
+// KeyPair는 세션 쿠키 서명(Auth)과 암호화(Encrypt)에 사용할 키 한 쌍입니다.
+// Encrypt가 비어 있으면 서명만 하고 암호화하지 않습니다.
+type KeyPair struct {
+	Auth    []byte
+	Encrypt []byte
+}
+
+// ParseKeys는 키 목록 문자열을 KeyPair 목록으로 변환합니다.
+//   - 키 쌍은 줄바꿈 또는 쉼표로 구분하며, 첫 번째 쌍이 새 세션 서명에 사용되고
+//     나머지 쌍은 기존 세션 검증에만 사용됩니다. (키 교체)
+//   - 각 쌍은 "서명키" 또는 "서명키:암호화키" 형식입니다.
+//   - "base64:" 접두어가 붙은 값은 base64로 디코딩합니다. 그 외에는 콜론을 포함할 수 없습니다.
+func ParseKeys(raw string) ([]KeyPair, error) {
+	var keys []KeyPair
+	entries := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ','
+	})
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		authRaw, encRaw, err := splitPair(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		auth, err := decodeKey(authRaw)
+		if err != nil {
+			return nil, err
+		}
+		if len(auth) < 32 {
+			return nil, fmt.Errorf("session auth key must be at least 32 bytes")
+		}
+		var enc []byte
+		if encRaw != "" {
+			enc, err = decodeKey(encRaw)
+			if err != nil {
+				return nil, err
+			}
+			if l := len(enc); l != 16 && l != 24 && l != 32 {
+				return nil, fmt.Errorf("session encryption key must be 16, 24 or 32 bytes")
+			}
+		}
+		keys = append(keys, KeyPair{Auth: auth, Encrypt: enc})
+	}
+	return keys, nil
+}
+
+// splitPair는 "서명키:암호화키" 항목을 나눕니다. "base64:" 접두어의 콜론은 구분자로 보지 않습니다.
+func splitPair(entry string) (string, string, error) {
+	var values []string
+	parts := strings.Split(entry, ":")
+	for i := 0; i < len(parts); i++ {
+		if parts[i] == "base64" && i+1 < len(parts) {
+			values = append(values, "base64:"+parts[i+1])
+			i++
+			continue
+		}
+		values = append(values, parts[i])
+	}
+	switch len(values) {
+	case 1:
+		return values[0], "", nil
+	case 2:
+		return values[0], values[1], nil
+	}
+	return "", "", fmt.Errorf("invalid session key entry")
+}
+
+func decodeKey(value string) ([]byte, error) {
+	if b64, ok := strings.CutPrefix(value, "base64:"); ok {
+		key, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 session key: %w", err)
+		}
+		return key, nil
+	}
+	return []byte(value), nil
+}
+
+// LoadKeys는 환경 변수 설정에 따라 세션 키를 로드합니다.
+// 다음 순서로 처음 설정된 값을 사용합니다.
+// - SESSION_KEYS: 키 목록 문자열
+// - SESSION_KEYS_FILE: 키 목록 파일 경로
+// - SESSION_SECRET: AWS Secrets Manager 시크릿 이름
+// 아무것도 설정되어 있지 않으면 빈 목록을 반환합니다.
+func LoadKeys(ctx context.Context, awsConfig func() (aws.Config, error)) ([]KeyPair, error) {
+	if raw := env.GetEnv("SESSION_KEYS", ""); raw != "" {
+		return ParseKeys(raw)
+	}
+
+	if path := env.GetEnv("SESSION_KEYS_FILE", ""); path != "" {
+		data, err := file.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read session keys file: %w", err)
+		}
+		return ParseKeys(string(data))
+	}
+
+	if secretName := env.GetEnv("SESSION_SECRET", ""); secretName != "" {
+		awsCfg, err := awsConfig()
+		if err != nil {
+			return nil, err
+		}
+		smClient := secretsmanager.NewFromConfig(awsCfg)
+		secret, err := smClient.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: &secretName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve secret %s: %w", secretName, err)
+		}
+		if secret.SecretString == nil {
+			return nil, fmt.Errorf("secret %s has no string value", secretName)
+		}
+		return ParseKeys(*secret.SecretString)
+	}
+
+	return nil, nil
+}
+
+// RandomKeys는 프로세스 수명 동안만 유효한 임의의 키를 생성합니다.
+// 재시작이나 다른 복제본에서는 세션을 검증할 수 없으므로 개발용으로만 사용해야 합니다.
+func RandomKeys() ([]KeyPair, error) {
+	auth := make([]byte, 64)
+	if _, err := rand.Read(auth); err != nil {
+		return nil, err
+	}
+	enc := make([]byte, 32)
+	if _, err := rand.Read(enc); err != nil {
+		return nil, err
+	}
+	return []KeyPair{{Auth: auth, Encrypt: enc}}, nil
+}
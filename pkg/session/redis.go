@@ -0,0 +1,204 @@
+// parkjunwoo.com/microstral/pkg/session/redis.go
+package session
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+	"github.com/redis/go-redis/v9"
+)
+
+// UserIDKey는 세션에 사용자 ID를 저장할 때 사용하는 키입니다.
+// RedisStore는 이 값으로 사용자별 세션 목록을 관리하여 RevokeUser를 지원합니다.
+const UserIDKey = "user_id"
+
+// RedisStore는 세션 데이터를 Redis에 저장하고 쿠키에는 서명된 세션 ID만 저장하는 세션 저장소입니다.
+// 모든 복제본이 같은 Redis를 바라보므로 한 곳에서 삭제된 세션은 모든 복제본에서 무효화됩니다.
+type RedisStore struct {
+	client  *redis.Client
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+	prefix  string
+}
+
+// NewRedisStore는 Redis 세션 저장소를 생성합니다.
+// 세션 TTL은 options.MaxAge(초)를 따르며, 0 이하이면 기본 1일을 사용합니다.
+func NewRedisStore(client *redis.Client, keys []KeyPair, options sessions.Options, prefix string) *RedisStore {
+	s := &RedisStore{
+		client: client,
+		codecs: securecookie.CodecsFromPairs(keyPairs(keys)...),
+		prefix: prefix,
+	}
+	s.Options(options)
+	return s
+}
+
+// Options는 세션 쿠키 옵션을 설정합니다. (sessions.Store 구현)
+func (s *RedisStore) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(s.ttlSeconds())
+		}
+	}
+}
+
+// Get은 요청 단위 레지스트리에 등록된 세션을 반환합니다.
+func (s *RedisStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+// New는 쿠키의 세션 ID로 Redis에서 세션을 불러오거나 새 세션을 생성합니다.
+func (s *RedisStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	opts := *s.options
+	session.Options = &opts
+	session.IsNew = true
+
+	c, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var id string
+	if err := securecookie.DecodeMulti(name, c.Value, &id, s.codecs...); err != nil {
+		return session, err
+	}
+	found, err := s.load(r.Context(), id, session)
+	if err != nil {
+		return session, err
+	}
+	if found {
+		session.ID = id
+		session.IsNew = false
+	}
+	return session, nil
+}
+
+// Save는 세션을 Redis에 저장하고 서명된 세션 ID 쿠키를 응답에 설정합니다.
+// session.Options.MaxAge가 0보다 작으면 Redis에서 세션을 삭제합니다.
+func (s *RedisStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	ctx := r.Context()
+
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.Revoke(ctx, session.ID); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		id, err := generateID()
+		if err != nil {
+			return err
+		}
+		session.ID = id
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(session.Values); err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	ttl := time.Duration(s.ttlSeconds()) * time.Second
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.sessionKey(session.ID), buf.Bytes(), ttl)
+	if userID, ok := session.Values[UserIDKey].(string); ok && userID != "" {
+		pipe.SAdd(ctx, s.userKey(userID), session.ID)
+		pipe.Expire(ctx, s.userKey(userID), ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// Revoke는 세션 ID에 해당하는 세션을 삭제합니다.
+func (s *RedisStore) Revoke(ctx context.Context, id string) error {
+	var values map[interface{}]interface{}
+	data, err := s.client.Get(ctx, s.sessionKey(id)).Bytes()
+	if err == nil {
+		_ = gob.NewDecoder(bytes.NewReader(data)).Decode(&values)
+	} else if !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, s.sessionKey(id))
+	if userID, ok := values[UserIDKey].(string); ok && userID != "" {
+		pipe.SRem(ctx, s.userKey(userID), id)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUser는 사용자 ID로 저장된 모든 세션을 삭제합니다. (모든 기기에서 로그아웃)
+func (s *RedisStore) RevokeUser(ctx context.Context, userID string) error {
+	ids, err := s.client.SMembers(ctx, s.userKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, s.sessionKey(id))
+	}
+	keys = append(keys, s.userKey(userID))
+	return s.client.Del(ctx, keys...).Err()
+}
+
+// load는 Redis에서 세션 값을 읽어옵니다. 세션이 없으면 false를 반환합니다.
+func (s *RedisStore) load(ctx context.Context, id string, session *gsessions.Session) (bool, error) {
+	data, err := s.client.Get(ctx, s.sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&session.Values); err != nil {
+		return false, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return true, nil
+}
+
+func (s *RedisStore) ttlSeconds() int {
+	if s.options == nil || s.options.MaxAge <= 0 {
+		return 60 * 60 * 24
+	}
+	return s.options.MaxAge
+}
+
+func (s *RedisStore) sessionKey(id string) string {
+	return s.prefix + id
+}
+
+func (s *RedisStore) userKey(userID string) string {
+	return s.prefix + "user:" + userID
+}
+
+func generateID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(base32.StdEncoding.EncodeToString(b), "="), nil
+}
@@ -0,0 +1,107 @@
+// parkjunwoo.com/microstral/pkg/session/store.go
+package session
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	gsessions "github.com/gorilla/sessions"
+
+	"parkjunwoo.com/microstral/pkg/env"
+)
+
+// OptionsFromEnv는 환경 변수에서 세션 쿠키 옵션을 읽어옵니다.
+// secure는 SESSION_SECURE를 설정하지 않았을 때의 Secure 값으로, 서버가 HTTPS로 서비스하는지를 넘깁니다.
+// HTTP로만 서비스하는데 Secure 쿠키를 발급하면 브라우저가 쿠키를 보내지 않아 세션이 유지되지 않습니다.
+// TLS를 종료하는 프록시 뒤에서 HTTP로 서비스한다면 SESSION_SECURE=true로 설정하십시오.
+func OptionsFromEnv(secure bool) sessions.Options {
+	return sessions.Options{
+		Path:     env.GetEnv("SESSION_PATH", "/"),
+		Domain:   env.GetEnv("SESSION_DOMAIN", ""),
+		MaxAge:   env.GetEnvInt("SESSION_MAX_AGE", 60*60*24), // 기본 1일
+		Secure:   env.GetEnvBool("SESSION_SECURE", secure),
+		HttpOnly: env.GetEnvBool("SESSION_HTTPONLY", true),
+		SameSite: ParseSameSite(env.GetEnv("SESSION_SAMESITE", "lax")),
+	}
+}
+
+// ParseSameSite는 "lax", "strict", "none" 문자열을 http.SameSite 값으로 변환합니다.
+func ParseSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
+
+// NewCookieStore는 주어진 키로 서명/암호화하는 쿠키 세션 저장소를 생성합니다.
+// 첫 번째 키 쌍으로 서명하고, 모든 키 쌍으로 검증합니다.
+func NewCookieStore(keys []KeyPair, options sessions.Options) sessions.Store {
+	store := cookie.NewStore(keyPairs(keys)...)
+	store.Options(options)
+	return store
+}
+
+// keyPairs는 KeyPair 목록을 gorilla securecookie 형식([서명키, 암호화키, ...])으로 펼칩니다.
+func keyPairs(keys []KeyPair) [][]byte {
+	pairs := make([][]byte, 0, len(keys)*2)
+	for _, key := range keys {
+		pairs = append(pairs, key.Auth, key.Encrypt)
+	}
+	return pairs
+}
+
+// Regenerate는 세션 값을 모두 지우고 다음 Save에서 새 세션 ID가 발급되도록 합니다.
+// 로그인처럼 권한이 바뀌는 시점에 호출하여 세션 고정(session fixation)을 막습니다.
+// Redis 저장소이면 이전 세션 ID를 즉시 폐기합니다.
+func Regenerate(ctx context.Context, s sessions.Session) error {
+	s.Clear()
+	gs := underlying(s)
+	if gs == nil {
+		return nil
+	}
+	previous := gs.ID
+	gs.ID = ""
+	gs.IsNew = true
+	if store, ok := gs.Store().(*RedisStore); ok && previous != "" {
+		return store.Revoke(ctx, previous)
+	}
+	return nil
+}
+
+// Expire는 세션을 비우고 세션 쿠키를 만료시킵니다.
+// 저장소에 설정된 쿠키 옵션(Path, Domain 등, WithSessionOptions 포함)을 그대로 사용하므로
+// 만료 쿠키가 발급된 쿠키와 일치합니다.
+func Expire(s sessions.Session) error {
+	// 저장소 옵션을 알 수 없으면 Secure 없이 만료시킴 (HTTPS 응답은 Secure 쿠키도 덮어쓸 수 있음)
+	options := OptionsFromEnv(false)
+	if gs := underlying(s); gs != nil && gs.Options != nil {
+		options = sessions.Options{
+			Path:     gs.Options.Path,
+			Domain:   gs.Options.Domain,
+			Secure:   gs.Options.Secure,
+			HttpOnly: gs.Options.HttpOnly,
+			SameSite: gs.Options.SameSite,
+		}
+	}
+	options.MaxAge = -1
+	s.Clear()
+	s.Options(options)
+	return s.Save()
+}
+
+// underlying은 gin-contrib 세션이 감싼 gorilla 세션을 반환합니다. 알 수 없는 구현이면 nil입니다.
+func underlying(s sessions.Session) *gsessions.Session {
+	if gs, ok := s.(interface{ Session() *gsessions.Session }); ok {
+		return gs.Session()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// parkjunwoo.com/microstral/pkg/session/store_test.go
+package session
+
+import "testing"
+
+func TestOptionsFromEnvSecure(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string // 빈 문자열은 미설정과 같음
+		https  bool
+		secure bool
+	}{
+		{"http default", "", false, false},
+		{"https default", "", true, true},
+		{"forced on behind TLS proxy", "true", false, true},
+		{"forced off", "false", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SESSION_SECURE", tt.env)
+			if got := OptionsFromEnv(tt.https).Secure; got != tt.secure {
+				t.Errorf("Secure = %v, want %v", got, tt.secure)
+			}
+		})
+	}
+}
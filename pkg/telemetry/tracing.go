@@ -0,0 +1,61 @@
+// parkjunwoo.com/microstral/pkg/telemetry/tracing.go
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"parkjunwoo.com/microstral/pkg/env"
+)
+
+// TracerName은 microstral이 생성하는 스팬의 계측 라이브러리 이름입니다.
+const TracerName = "parkjunwoo.com/microstral"
+
+// NewTracerProvider는 환경 변수 설정으로 TracerProvider를 생성합니다.
+// - OTEL_SERVICE_NAME: 서비스 이름 (기본값 HOST 또는 mist)
+// - OTEL_TRACES_EXPORTER: otlp 또는 none (기본값 otlp)
+// - OTEL_TRACES_SAMPLER_ARG: 샘플링 비율 0~1 (기본값 1)
+// - OTEL_EXPORTER_OTLP_ENDPOINT 등 OTLP 설정은 otlptracehttp가 직접 읽습니다.
+func NewTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	var opts []sdktrace.TracerProviderOption
+
+	switch exporter := strings.ToLower(env.GetEnv("OTEL_TRACES_EXPORTER", "otlp")); exporter {
+	case "otlp":
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case "none":
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", exporter)
+	}
+
+	ratio := env.GetEnvFloat64("OTEL_TRACES_SAMPLER_ARG", 1)
+	opts = append(opts, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))))
+
+	return newTracerProvider(opts...), nil
+}
+
+// Install은 tp를 전역 TracerProvider로 등록하고 W3C Trace Context/Baggage 전파를 설정합니다.
+// mttp, Postgres, Redis, OPA 계측은 전역 TracerProvider를 사용하므로 등록 전에는 스팬이 기록되지 않습니다.
+func Install(tp *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+}
+
+func newTracerProvider(opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	serviceName := env.GetEnv("OTEL_SERVICE_NAME", env.GetEnv("HOST", "mist"))
+	res := resource.NewSchemaless(semconv.ServiceName(serviceName))
+	return sdktrace.NewTracerProvider(append([]sdktrace.TracerProviderOption{sdktrace.WithResource(res)}, opts...)...)
+}
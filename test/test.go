@@ -2,25 +2,20 @@
 package test
 
 import (
-	"context"
-
-	"github.com/aws/aws-sdk-go-v2/config"
 	mist "parkjunwoo.com/microstral"
 	"parkjunwoo.com/microstral/pkg/auth"
 	"parkjunwoo.com/microstral/pkg/cloudfront"
-	"parkjunwoo.com/microstral/pkg/env"
 	"parkjunwoo.com/microstral/pkg/middleware"
 )
 
 func main() {
 	// Mist 서버 생성
-	s, err := mist.New(false, true)
+	s, err := mist.New(mist.WithHTTP(false), mist.WithHTTPS(true))
 	if err != nil {
 		panic(err)
 	}
 	// AWS 설정 로드
-	region := env.GetEnv("AWS_REGION", "")
-	awsCfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(region))
+	awsCfg, err := s.GetAWSConfig()
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,232 @@
+// parkjunwoo.com/microstral/version.go
+package mist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"parkjunwoo.com/microstral/pkg/problem"
+)
+
+// HeaderAcceptVersion은 클라이언트가 API 버전을 지정하는 요청 헤더입니다.
+const HeaderAcceptVersion = "Accept-Version"
+
+// unsupportedVersionKey는 지원하지 않는 Accept-Version 값을 엔진의 미들웨어로 넘기는 컨텍스트 키입니다.
+type unsupportedVersionKey struct{}
+
+// apiVersion은 Version으로 등록한 API 버전의 설정입니다.
+type apiVersion struct {
+	name       string
+	deprecated time.Time
+	sunset     time.Time
+	successor  string
+}
+
+// VersionOption은 Version에 전달되어 API 버전 설정을 변경합니다.
+type VersionOption func(*apiVersion)
+
+// Deprecated: 버전이 폐기 예정임을 Deprecation 헤더로 알립니다. (RFC 9745)
+// at은 폐기된(될) 시각입니다.
+func Deprecated(at time.Time) VersionOption {
+	return func(v *apiVersion) {
+		v.deprecated = at
+	}
+}
+
+// Sunset: 버전을 더 이상 제공하지 않을 시각을 Sunset 헤더로 알립니다. (RFC 8594)
+func Sunset(at time.Time) VersionOption {
+	return func(v *apiVersion) {
+		v.sunset = at
+	}
+}
+
+// Successor: 대체 버전 문서/경로를 Link 헤더(rel="successor-version")로 알립니다.
+func Successor(link string) VersionOption {
+	return func(v *apiVersion) {
+		v.successor = link
+	}
+}
+
+// Version: /{name} 경로에 API 버전 그룹 생성
+// 경로에 버전이 없는 요청도 Accept-Version 헤더(예: "v1" 또는 "1")로 해당 버전에 라우팅되며,
+// 헤더가 없으면 API_DEFAULT_VERSION 버전으로 라우팅됩니다.
+// 예: v1 := s.Version("v1", mist.Deprecated(t1), mist.Sunset(t2), mist.Successor("/v2"))
+func (s *Mist) Version(name string, opts ...VersionOption) *Group {
+	v := &apiVersion{name: normalizeVersion(name)}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if s.versions == nil {
+		s.versions = make(map[string]*apiVersion)
+	}
+	s.versions[v.name] = v
+
+	var handlers []gin.HandlerFunc
+	if !v.deprecated.IsZero() || !v.sunset.IsZero() || v.successor != "" {
+		handlers = append(handlers, versionHeaders(v))
+	}
+	return s.Group("/"+v.name, handlers...)
+}
+
+// versionHeaders는 폐기 예정 버전의 응답에 Deprecation/Sunset/Link 헤더를 추가합니다.
+func versionHeaders(v *apiVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !v.deprecated.IsZero() {
+			c.Header("Deprecation", fmt.Sprintf("@%d", v.deprecated.Unix()))
+		}
+		if !v.sunset.IsZero() {
+			c.Header("Sunset", v.sunset.UTC().Format(http.TimeFormat))
+		}
+		if v.successor != "" {
+			c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, v.successor))
+		}
+		c.Next()
+	}
+}
+
+// versionRouter는 경로에 버전이 없는 요청을 Accept-Version 헤더 또는 기본 버전 경로로 바꿉니다.
+// 라우팅 전에 경로를 바꿔야 하므로 gin 미들웨어가 아닌 http.Handler로 엔진을 감쌉니다.
+// 라우트 표는 첫 요청에서 한 번만 만들므로, 라우트는 서버를 시작하기 전에 모두 등록해야 합니다.
+func (s *Mist) versionRouter(next http.Handler) http.Handler {
+	var once sync.Once
+	var routes *routeTable
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(s.versions) == 0 || s.hasVersionPrefix(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		once.Do(func() { routes = newRouteTable(s.router.Routes()) })
+		// 버전 없이 등록된 라우트(/live, /metrics 등)는 그대로 처리
+		if routes.match(r.Method, r.URL.Path, false) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		name := s.cfg.defaultVersion
+		if header := r.Header.Get(HeaderAcceptVersion); header != "" {
+			name = normalizeVersion(header)
+			if _, ok := s.versions[name]; !ok {
+				// 요청 ID, 로깅, 메트릭이 적용되도록 오류 응답은 엔진 안의 versionCheck가 작성
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), unsupportedVersionKey{}, header)))
+				return
+			}
+		}
+		if _, ok := s.versions[name]; !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// 루트의 /:param, /*any 라우트는 버전 경로에 맞는 라우트가 없을 때만 사용
+		if !routes.match(r.Method, "/"+name+r.URL.Path, true) && routes.matchRoot(r.Method, r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = "/" + name + r.URL.Path
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = "/" + name + r.URL.RawPath
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// versionCheck는 versionRouter가 표시한 지원하지 않는 Accept-Version 요청을 400으로 중단합니다.
+func versionCheck() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if header, ok := c.Request.Context().Value(unsupportedVersionKey{}).(string); ok {
+			problem.Abort(c, problem.BadRequest("unsupported_version", fmt.Sprintf("API version %q is not supported", header)))
+			return
+		}
+		c.Next()
+	}
+}
+
+func (s *Mist) hasVersionPrefix(path string) bool {
+	first, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	_, ok := s.versions[first]
+	return ok
+}
+
+// routeTable은 엔진에 등록된 라우트를 요청마다 Routes()를 부르지 않고 찾기 위한 표입니다.
+type routeTable struct {
+	static  map[string]bool // 파라미터 없는 라우트 ("GET /live")
+	dynamic []gin.RouteInfo // 첫 세그먼트가 고정된 파라미터 라우트 ("/users/:id")
+	root    []gin.RouteInfo // 첫 세그먼트부터 파라미터인 라우트 ("/:slug", "/*any")
+}
+
+func newRouteTable(routes gin.RoutesInfo) *routeTable {
+	t := &routeTable{static: make(map[string]bool, len(routes))}
+	for _, route := range routes {
+		switch {
+		case !strings.ContainsAny(route.Path, ":*"):
+			t.static[route.Method+" "+route.Path] = true
+		case strings.HasPrefix(route.Path, "/:") || strings.HasPrefix(route.Path, "/*"):
+			t.root = append(t.root, route)
+		default:
+			t.dynamic = append(t.dynamic, route)
+		}
+	}
+	return t
+}
+
+// match는 method와 path에 맞는 라우트가 있는지 확인합니다. root가 false이면 루트 파라미터 라우트는 제외합니다.
+func (t *routeTable) match(method, path string, root bool) bool {
+	if t.static[method+" "+path] {
+		return true
+	}
+	for _, route := range t.dynamic {
+		if route.Method == method && matchRoute(route.Path, path) {
+			return true
+		}
+	}
+	return root && t.matchRoot(method, path)
+}
+
+// matchRoot는 루트 파라미터 라우트 중 method와 path에 맞는 라우트가 있는지 확인합니다.
+func (t *routeTable) matchRoot(method, path string) bool {
+	for _, route := range t.root {
+		if route.Method == method && matchRoute(route.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoute는 gin 라우트 패턴(:param, *catchall)이 path와 일치하는지 확인합니다.
+func matchRoute(pattern, path string) bool {
+	ps := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	ss := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, p := range ps {
+		if strings.HasPrefix(p, "*") {
+			return true
+		}
+		if i >= len(ss) {
+			return false
+		}
+		if strings.HasPrefix(p, ":") {
+			if ss[i] == "" {
+				return false
+			}
+			continue
+		}
+		if p != ss[i] {
+			return false
+		}
+	}
+	return len(ps) == len(ss)
+}
+
+// normalizeVersion은 "1", "V1", "v1"을 모두 "v1"로 바꿉니다.
+func normalizeVersion(name string) string {
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "/"))
+	if name != "" && !strings.HasPrefix(name, "v") {
+		name = "v" + name
+	}
+	return name
+}